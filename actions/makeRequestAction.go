@@ -1,18 +1,35 @@
 package actions
 
-import "github.com/joakim-ribier/gttp/httpclient"
+import (
+	"time"
+
+	"github.com/joakim-ribier/gttp/httpclient"
+	"github.com/joakim-ribier/gttp/models"
+)
 
 type MakeRequestAction struct {
 	DisplayResponse     func(client *httpclient.HTTPClient, data string)
 	DisplayErrorRequest func(message string, mode string)
+	DisplayProgress     func(elapsed time.Duration)
+	DisplayCancelled    func(elapsed time.Duration)
+	DisplayAssertions   func(results []models.AssertionResult)
+	DisplayExtractions  func(results []models.ExtractionResult)
 }
 
 func NewMakeRequestAction(
 	displayResponse func(client *httpclient.HTTPClient, data string),
-	displayErrorRequest func(message string, mode string)) *MakeRequestAction {
+	displayErrorRequest func(message string, mode string),
+	displayProgress func(elapsed time.Duration),
+	displayCancelled func(elapsed time.Duration),
+	displayAssertions func(results []models.AssertionResult),
+	displayExtractions func(results []models.ExtractionResult)) *MakeRequestAction {
 
 	return &MakeRequestAction{
 		DisplayResponse:     displayResponse,
 		DisplayErrorRequest: displayErrorRequest,
+		DisplayProgress:     displayProgress,
+		DisplayCancelled:    displayCancelled,
+		DisplayAssertions:   displayAssertions,
+		DisplayExtractions:  displayExtractions,
 	}
 }
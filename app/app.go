@@ -1,6 +1,7 @@
 package app
 
 import (
+	"errors"
 	"os"
 	"strings"
 	"time"
@@ -41,13 +42,18 @@ var (
 
 	// List of controllers
 	makeRequestController *controllers.MakeRequestController
+	runnerController      *controllers.RunnerController
 
 	// List of services
 	appDataService *services.ApplicationDataService
+	historyService *services.HistoryService
+	secretService  *services.SecretService
 
 	// List of views of the application
 	expertModeView      *views.RequestExpertModeView
 	settingsView        *views.SettingsView
+	historyView         *views.HistoryView
+	jsonTreeView        *views.JSONTreeView
 	requestResponseView *views.RequestResponseView
 
 	// List of components of the application
@@ -97,6 +103,13 @@ func App() {
 	}
 
 	appDataService = services.NewApplicationDataService(getFilenameFromArgs(os.Args), log)
+	historyService = services.NewHistoryService(getFilenameFromArgs(os.Args), log)
+	secretService = services.NewSecretService(getFilenameFromArgs(os.Args), log)
+	if passphrase := os.Getenv("GTTP_PASSPHRASE"); passphrase != "" {
+		if error := secretService.Unlock(passphrase); error != nil {
+			log("Impossible to unlock the secrets: "+error.Error(), "error")
+		}
+	}
 
 	ctx = models.NewAppCtx(
 		getRootPrmt,
@@ -115,8 +128,12 @@ func App() {
 
 	// Fixme: To delete
 	mapFocusPrmtToShortutText[requestResponseView.ResponsePrmt] = utils.ResultShortcutsText
+	mapFocusPrmtToShortutText[requestResponseView.RequestPrmt] = utils.RequestShortcutsText
 	mapFocusPrmtToShortutText[expertModeView.TitlePrmt] = utils.ExpertModeShortcutsText
 	mapFocusPrmtToShortutText[settingsView.TitlePrmt] = utils.SettingsShortcutsText
+	mapFocusPrmtToShortutText[historyView.TablePrmt] = utils.HistoryShortcutsText
+	mapFocusPrmtToShortutText[runnerController.View.FormPrmt] = utils.RunnerShortcutsText
+	mapFocusPrmtToShortutText[jsonTreeView.TreePrmt] = utils.JSONTreeShortcutsText
 
 	refresh("all")
 
@@ -154,18 +171,32 @@ func App() {
 			switchPage("ExpertRequestView")
 		case tcell.KeyCtrlJ:
 			focusPrimitive(treeAPICpnt.RootPrmt, nil)
+		case tcell.KeyCtrlL:
+			switchPage("RunnerView")
 		case tcell.KeyCtrlN:
 			makeRequestController.New()
 		case tcell.KeyCtrlO:
 			switchPage("SettingsView")
+		case tcell.KeyCtrlP:
+			if requestResponseView.ResponsePrmt.HasFocus() {
+				requestResponseView.TogglePretty()
+			}
 		case tcell.KeyCtrlQ:
 			app.Stop()
 		case tcell.KeyCtrlR:
 			displayRequestResponseViewPage(requestResponseView.RequestPrmt)
 		case tcell.KeyCtrlS:
 			makeRequestController.Save()
+		case tcell.KeyCtrlT:
+			switchPage("HistoryView")
+		case tcell.KeyCtrlG:
+			makeRequestController.ImportCurl()
 		case tcell.KeyCtrlW:
 			displayRequestResponseViewPage(requestResponseView.ResponsePrmt)
+		case tcell.KeyCtrlX:
+			makeRequestController.Cancel()
+		case tcell.KeyCtrlY:
+			switchPage("JSONTreeView")
 		case tcell.KeyEsc:
 			focusPrimitive(logEventTextPrmt, nil)
 		}
@@ -185,7 +216,7 @@ func drawMainComponents(app *tview.Application) *tview.Pages {
 			refreshMDRView(it)
 		}, func(page string) {
 			pages.SwitchToPage(page)
-		})
+		}, runProject)
 
 		flex := utils.MakeTitlePrmt(utils.TreePrmtTitle)
 		flex.SetBorder(false)
@@ -198,23 +229,47 @@ func drawMainComponents(app *tview.Application) *tview.Pages {
 	}
 
 	drawRightPanel := func() tview.Primitive {
+		requestService := services.NewRequestService(getOutput, secretService)
 
 		makeRequestExportModeView := func() tview.Primitive {
-			expertModeView = views.NewRequestExpertModeView(app, ctx)
+			// the secret values of the selected execution context are masked
+			maskSecrets := func(value string) string {
+				_, env := makeRequestController.View.GetContext()
+				return secretService.Mask(env, value)
+			}
+			expertModeView = views.NewRequestExpertModeView(app, ctx, maskSecrets, requestService.ResolveSnippet)
 			expertModeView.InitView()
 
 			return expertModeView.ParentPrmt
 		}
 
+		makeHistoryView := func() tview.Primitive {
+			historyView = views.NewHistoryView(app, ctx, reopenHistoryEntry, replayHistoryEntry, clearHistory)
+			historyView.InitView()
+
+			return historyView.ParentPrmt
+		}
+
+		makeJSONTreeView := func() tview.Primitive {
+			jsonTreeView = views.NewJSONTreeView(app, ctx, func(value string) {
+				utils.WriteToClipboard(value, log)
+			}, addContextVariable)
+			jsonTreeView.InitView()
+
+			return jsonTreeView.ParentPrmt
+		}
+
 		makeSettingsView := func() tview.Primitive {
-			settingsView = views.NewSettingsView(app, ctx)
+			settingsView = views.NewSettingsView(app, ctx, importData, appDataService.Export, secretService.Unlock, secretService.Set, secretService.Remove)
 			settingsView.InitView()
 
 			return settingsView.ParentPrmt
 		}
 
 		// build request response view
-		requestResponseView = views.NewRequestResponseView(app, ctx)
+		requestResponseView = views.NewRequestResponseView(app, ctx, func(expression string) {
+			makeRequestController.SaveResponseFilter(expression)
+		})
 		requestResponseView.InitView()
 
 		focusPrmts = append(focusPrmts, requestResponseView.ResponsePrmt)
@@ -224,8 +279,24 @@ func drawMainComponents(app *tview.Application) *tview.Pages {
 		makeRequestController = controllers.NewMakeRequestController(
 			app,
 			appDataService,
+			historyService,
+			requestService,
+			ctx,
+			actions.NewMakeRequestAction(
+				requestResponseView.Display,
+				requestResponseView.Logger,
+				requestResponseView.DisplayProgress,
+				requestResponseView.DisplayCancelled,
+				requestResponseView.DisplayAssertions,
+				requestResponseView.DisplayExtractions),
+			log)
+
+		// build "collection runner" controller
+		runnerController = controllers.NewRunnerController(
+			app,
+			services.NewRunnerService(requestService),
 			ctx,
-			actions.NewMakeRequestAction(requestResponseView.Display, requestResponseView.Logger))
+			log)
 
 		flex := tview.NewFlex().SetDirection(tview.FlexRow)
 		flex.SetBorder(false)
@@ -236,6 +307,9 @@ func drawMainComponents(app *tview.Application) *tview.Pages {
 
 		pages.AddPage("RequestResponseViewPage", requestResponseView.ParentPrmt, true, false)
 		pages.AddPage("RequestExpertModeViewPage", makeRequestExportModeView(), true, false)
+		pages.AddPage("HistoryViewPage", makeHistoryView(), true, false)
+		pages.AddPage("RunnerViewPage", runnerController.Draw(), true, false)
+		pages.AddPage("JSONTreeViewPage", makeJSONTreeView(), true, false)
 		pages.AddPage("SettingsViewPage", makeSettingsView(), true, true)
 
 		flex.AddItem(makeRequestController.Draw(), 9, 0, false)
@@ -280,6 +354,43 @@ func executeRequest() {
 	makeRequestController.Execute()
 }
 
+// reopenHistoryEntry loads the request of the history @entry in the expert mode view
+func reopenHistoryEntry(entry models.HistoryEntry) {
+	refreshMDRView(entry.Request)
+	makeRequestController.View.SetContext(entry.Context)
+	switchPage("ExpertRequestView")
+}
+
+// replayHistoryEntry loads the request of the history @entry and executes it
+func replayHistoryEntry(entry models.HistoryEntry) {
+	refreshMDRView(entry.Request)
+	makeRequestController.View.SetContext(entry.Context)
+	executeRequest()
+}
+
+// runProject displays the collection runner view and runs all the requests of the @projectName
+func runProject(projectName string) {
+	switchPage("RunnerView")
+	runnerController.RunProject(projectName)
+}
+
+// importData imports the @filename data in the configuration app file and refreshes all views
+func importData(format string, filename string) ([]string, error) {
+	warnings, error := appDataService.Import(format, filename)
+	if error == nil {
+		refresh("all")
+	}
+	return warnings, error
+}
+
+func clearHistory() {
+	history := historyService.Load()
+	history.Clear()
+	historyService.Save(history)
+
+	refreshingHistory()
+}
+
 func focusPrimitive(prmt tview.Primitive, box *tview.Box) {
 	app.SetFocus(prmt)
 
@@ -342,6 +453,24 @@ func updateContext(value models.Context) {
 	refreshingContext()
 }
 
+// addContextVariable adds (or replaces) the @variable in the selected execution context
+func addContextVariable(variable string, value string) error {
+	name := models.ContextVariableName(variable)
+	if name == "" || name == "{}" || strings.ContainsAny(name, " \t") {
+		return errors.New("invalid variable name '" + variable + "'")
+	}
+
+	_, env := makeRequestController.View.GetContext()
+	if env == "" {
+		env = "default"
+	}
+
+	context := output.Context.Copy()
+	context.Add(env, name, value)
+	updateContext(context)
+	return nil
+}
+
 func refreshingConfig() {
 	for key, value := range ctx.AddListenerConfig {
 		ctx.PrintTrace("App.refreshingConfig." + key)
@@ -356,6 +485,14 @@ func refreshingContext() {
 	}
 }
 
+func refreshingHistory() {
+	history := historyService.Load()
+	for key, value := range ctx.AddListenerHistory {
+		ctx.PrintTrace("App.refreshingHistory." + key)
+		value(history)
+	}
+}
+
 func refreshingTreeAPICpn() {
 	treeAPICpnt.Refresh()
 }
@@ -374,11 +511,30 @@ func log(message string, status string) {
 func switchPage(page string) {
 	switch page {
 	case "ExpertRequestView":
+		// the URL (and its query params) could have been typed since the last refresh
+		for _, key := range []string{"requestExpertModeViewQueryParamsPage", "requestExpertModeViewPathParamsPage", "requestExpertModeViewPreviewPage"} {
+			if update, is := ctx.AddListenerMRD[key]; is {
+				update(getMDR())
+			}
+		}
 		pages.SwitchToPage("RequestExpertModeViewPage")
 		focusPrimitive(expertModeView.TitlePrmt, nil)
 	case "SettingsView":
 		pages.SwitchToPage("SettingsViewPage")
 		focusPrimitive(settingsView.TitlePrmt, nil)
+	case "HistoryView":
+		pages.SwitchToPage("HistoryViewPage")
+		focusPrimitive(historyView.TablePrmt, nil)
+	case "RunnerView":
+		pages.SwitchToPage("RunnerViewPage")
+		focusPrimitive(runnerController.View.FormPrmt, nil)
+	case "JSONTreeView":
+		if error := jsonTreeView.Display(requestResponseView.GetBody()); error != nil {
+			log("The response is not a valid json: "+error.Error(), "error")
+			return
+		}
+		pages.SwitchToPage("JSONTreeViewPage")
+		focusPrimitive(jsonTreeView.TreePrmt, nil)
 	}
 }
 
@@ -395,6 +551,7 @@ func refresh(value string) {
 		refreshingTreeAPICpn()
 		refreshingConfig()
 		refreshingContext()
+		refreshingHistory()
 		refreshMRDAllViews()
 	} else {
 		if strings.Contains(value, "tree") {
@@ -409,6 +566,9 @@ func refresh(value string) {
 		if strings.Contains(value, "request") {
 			refreshMRDAllViews()
 		}
+		if strings.Contains(value, "history") {
+			refreshingHistory()
+		}
 	}
 }
 
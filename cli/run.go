@@ -0,0 +1,303 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joakim-ribier/gttp/models"
+	"github.com/joakim-ribier/gttp/services"
+)
+
+// Represents the exit codes of the "run" command
+const (
+	ExitOK          = 0
+	ExitError       = 1
+	ExitUsage       = 2
+	ExitClientError = 4
+	ExitServerError = 5
+)
+
+// PassphraseEnv is the environment variable of the passphrase which unlocks the secret variables
+const PassphraseEnv = "GTTP_PASSPHRASE"
+
+// runResult represents the json output of the "run" command
+type runResult struct {
+	Project    string              `json:"project"`
+	Alias      string              `json:"alias"`
+	Env        string              `json:"env"`
+	Method     string              `json:"method"`
+	URL        string              `json:"url"`
+	Status     string              `json:"status,omitempty"`
+	StatusCode int                 `json:"statusCode,omitempty"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+	DurationMs int64               `json:"durationMs"`
+	Body       string              `json:"body,omitempty"`
+	Error      string              `json:"error,omitempty"`
+	Assertions []assertionResult   `json:"assertions,omitempty"`
+}
+
+// assertionResult represents the json output of an evaluated assertion
+type assertionResult struct {
+	Assertion string `json:"assertion"`
+	Passed    bool   `json:"passed"`
+	Message   string `json:"message,omitempty"`
+}
+
+// Run executes a saved request without the terminal UI and returns the exit code
+// (ex. "gttp run data.json --project Jira --alias "List tickets" --env prod").
+func Run(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: gttp run <data.json> --project <name> --alias <alias> [--env <env>] [--json] [--verbose]")
+		fmt.Fprintln(stderr, "       gttp run <data.json> --project <name> [--env <env>] [--stop-on-failure] [--junit <report.xml>]")
+		fmt.Fprintln(stderr)
+		flags.PrintDefaults()
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Exit code: 0 (2xx, 3xx), 4 (4xx), 5 (5xx), 1 (execution error, failed assertion or project failure), 2 (usage error)")
+	}
+
+	project := flags.String("project", "", "project name of the request (empty or \".\" for no project)")
+	alias := flags.String("alias", "", "alias of the request (empty to run all the requests of the project)")
+	env := flags.String("env", "default", "execution context (environment)")
+	jsonOutput := flags.Bool("json", false, "print the result as json")
+	verbose := flags.Bool("verbose", false, "print the request, the response status/headers & the timing to stderr")
+	stopOnFailure := flags.Bool("stop-on-failure", false, "stop the project run on the first failure")
+	junit := flags.String("junit", "", "write the project run results as JUnit XML in this file")
+
+	filename, err := parseArgs(flags, args)
+	if err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintln(stderr, "Error:", err)
+		}
+		return ExitUsage
+	}
+
+	if _, err := os.Stat(filename); err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return ExitUsage
+	}
+
+	logger := func(message string, mode string) {
+		if *verbose || mode == "error" {
+			fmt.Fprintln(stderr, "["+strings.ToUpper(mode)+"]", message)
+		}
+	}
+
+	// the environments are saved in lowercase
+	*env = strings.ToLower(*env)
+
+	output := services.NewApplicationDataService(filename, logger).Load()
+	if output.Context.GetEnvsName().GetIndex(*env) == -1 {
+		fmt.Fprintln(stderr, "Error: env '"+*env+"' does not exist")
+		return ExitUsage
+	}
+
+	// The secret variables are resolved with the passphrase of the secrets file (if defined)
+	secretService := services.NewSecretService(filename, logger)
+	if passphrase := os.Getenv(PassphraseEnv); passphrase != "" {
+		if err := secretService.Unlock(passphrase); err != nil {
+			fmt.Fprintln(stderr, "Error: impossible to unlock the secrets:", err)
+			return ExitUsage
+		}
+	}
+
+	requestService := services.NewRequestService(func() models.Output { return output }, secretService)
+
+	if *alias == "" {
+		return runProject(services.NewRunnerService(requestService), *project, *env, *stopOnFailure, *junit, *jsonOutput, stdout, stderr)
+	}
+
+	makeRequestData, err := output.FindByAlias(*project, *alias)
+	if err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return ExitUsage
+	}
+
+	request, err := requestService.Resolve(makeRequestData, *env)
+	if err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return ExitUsage
+	}
+
+	result := runResult{
+		Project: makeRequestData.ProjectName,
+		Alias:   makeRequestData.Alias,
+		Env:     *env,
+		Method:  request.Method.String(),
+		URL:     requestService.Mask(*env, request.URL.String()),
+	}
+
+	if *verbose {
+		fmt.Fprintln(stderr, "> "+request.Method.String()+" "+requestService.Mask(*env, request.URL.String()))
+		for _, key := range request.Headers.ToSortedKeys() {
+			fmt.Fprintln(stderr, "> "+key+": "+requestService.Mask(*env, request.Headers[key]))
+		}
+	}
+
+	start := time.Now()
+	client, err := requestService.Call(context.Background(), request, func(message string, mode string) {
+		logger(requestService.Mask(*env, message), mode)
+	})
+	duration := time.Since(start)
+	result.DurationMs = duration.Milliseconds()
+
+	if err != nil {
+		result.Error = err.Error()
+		printResult(stdout, stderr, result, *jsonOutput)
+		return ExitError
+	}
+
+	response := client.Response.Response
+	result.Status = response.Status
+	result.StatusCode = response.StatusCode
+	result.Headers = response.Header
+	result.Body = string(client.Body)
+
+	if *verbose {
+		fmt.Fprintln(stderr, "< "+response.Proto+" "+response.Status)
+		for _, key := range client.SortedHeadersResponse() {
+			for _, value := range client.HeadersResponse[key] {
+				fmt.Fprintln(stderr, "< "+key+": "+value)
+			}
+		}
+		if client.Proxy != "" {
+			fmt.Fprintln(stderr, "* proxy="+client.Proxy)
+		}
+		fmt.Fprintln(stderr, "* "+client.Timing.String())
+	}
+
+	assertions := requestService.Assert(request, client, duration)
+	for _, assertion := range assertions {
+		result.Assertions = append(result.Assertions, assertionResult{
+			Assertion: assertion.Assertion.String(),
+			Passed:    assertion.Passed,
+			Message:   assertion.Message,
+		})
+		if *verbose || !assertion.Passed {
+			state := "PASS"
+			if !assertion.Passed {
+				state = "FAIL"
+			}
+			fmt.Fprintln(stderr, state, assertion.Assertion.String(), "("+assertion.Message+")")
+		}
+	}
+
+	printResult(stdout, stderr, result, *jsonOutput)
+	if models.FailedAssertions(assertions) > 0 {
+		return ExitError
+	}
+	return ExitCode(response.StatusCode)
+}
+
+// runProject executes all the requests of the @projectName and prints one line per request (or json)
+func runProject(
+	runnerService *services.RunnerService,
+	projectName string,
+	env string,
+	stopOnFailure bool,
+	junit string,
+	jsonOutput bool,
+	stdout io.Writer,
+	stderr io.Writer) int {
+
+	if len(runnerService.Requests(projectName)) == 0 {
+		fmt.Fprintln(stderr, "Error: project '"+projectName+"' does not exist")
+		return ExitUsage
+	}
+
+	runner := runnerService.Run(context.Background(), runnerService.RequestService.GetOutput(), projectName, env, stopOnFailure, func(index int, result models.RunnerResult) {
+		if jsonOutput {
+			return
+		}
+		state := "PASS"
+		if !result.Passed() {
+			state = "FAIL"
+		}
+		fmt.Fprintln(stdout, state, result.Name(), result.StatusCode, result.Duration.Round(time.Millisecond), result.Failure())
+	})
+
+	if jsonOutput {
+		var results []runResult
+		for _, result := range runner.Results {
+			results = append(results, runResult{
+				Project:    projectName,
+				Alias:      result.Request.Alias,
+				Env:        env,
+				Method:     result.Request.Method.String(),
+				URL:        result.URL,
+				Status:     result.Status,
+				StatusCode: result.StatusCode,
+				DurationMs: result.Duration.Milliseconds(),
+				Error:      result.Failure(),
+			})
+		}
+		data, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Fprintln(stdout, string(data))
+	}
+
+	passed, failed, skipped := runner.Count()
+	fmt.Fprintln(stderr, passed, "passed,", failed, "failed,", skipped, "skipped in", runner.Duration().Round(time.Millisecond))
+
+	if junit != "" {
+		if err := runnerService.ExportJUnit(runner, junit); err != nil {
+			fmt.Fprintln(stderr, "Error:", err)
+			return ExitError
+		}
+	}
+
+	if failed > 0 {
+		return ExitError
+	}
+	return ExitOK
+}
+
+// ExitCode returns the exit code of the HTTP @statusCode
+func ExitCode(statusCode int) int {
+	switch {
+	case statusCode >= 500:
+		return ExitServerError
+	case statusCode >= 400:
+		return ExitClientError
+	default:
+		return ExitOK
+	}
+}
+
+// parseArgs parses the flags which could be set before or after the data file name
+func parseArgs(flags *flag.FlagSet, args []string) (string, error) {
+	var filename string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		filename, args = args[0], args[1:]
+	}
+	if err := flags.Parse(args); err != nil {
+		return "", err
+	}
+	if filename == "" && flags.NArg() > 0 {
+		filename = flags.Arg(0)
+	}
+	if filename == "" {
+		return "", errors.New("missing data file")
+	}
+	return filename, nil
+}
+
+func printResult(stdout io.Writer, stderr io.Writer, result runResult, jsonOutput bool) {
+	if jsonOutput {
+		data, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Fprintln(stdout, string(data))
+		return
+	}
+	if result.Error != "" {
+		fmt.Fprintln(stderr, "Error:", result.Error)
+		return
+	}
+	fmt.Fprint(stdout, result.Body)
+}
@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/joakim-ribier/gttp/core"
+	"github.com/joakim-ribier/gttp/models"
+	"github.com/joakim-ribier/gttp/models/types"
+)
+
+func writeDataFile(t *testing.T, url string) string {
+	output := models.Output{
+		Data: []models.MakeRequestData{
+			{Method: "GET", URL: "{host}/tickets", ProjectName: "Jira", Alias: "List tickets"},
+			{Method: "GET", URL: "{host}/missing", ProjectName: "Jira", Alias: "Missing"},
+		},
+		Context: models.Context{
+			Env: map[string][]models.ContextVariable{
+				"default": {{Variable: "{host}", Value: "http://localhost"}},
+				"prod":    {{Variable: "{host}", Value: url}},
+			},
+		},
+	}
+	data, _ := json.Marshal(output)
+	filename := filepath.Join(t.TempDir(), "data.json")
+	if err := ioutil.WriteFile(filename, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("tickets"))
+	}))
+	defer server.Close()
+
+	filename := writeDataFile(t, server.URL)
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{filename, "--project", "Jira", "--alias", "List tickets", "--env", "prod"}, &stdout, &stderr)
+	if code != ExitOK || stdout.String() != "tickets" {
+		t.Error("Expected 0 'tickets', got ", code, stdout.String(), stderr.String())
+	}
+
+	stdout.Reset()
+	code = Run([]string{"--project", "Jira", "--alias", "Missing", "--env", "prod", "--json", filename}, &stdout, &stderr)
+	var result runResult
+	json.Unmarshal(stdout.Bytes(), &result)
+	if code != ExitClientError || result.StatusCode != 404 || result.URL != server.URL+"/missing" {
+		t.Error("Expected 4 404 json result, got ", code, stdout.String())
+	}
+}
+
+func TestRunUsageError(t *testing.T) {
+	filename := writeDataFile(t, "http://localhost")
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{filename, "--project", "Jira", "--alias", "Unknown"}, &stdout, &stderr); code != ExitUsage {
+		t.Error("Expected 2, got ", code)
+	}
+	if code := Run([]string{filename, "--project", "Jira", "--alias", "List tickets", "--env", "dev"}, &stdout, &stderr); code != ExitUsage {
+		t.Error("Expected 2, got ", code)
+	}
+	if code := Run([]string{"--alias", "List tickets"}, &stdout, &stderr); code != ExitUsage || !strings.Contains(stderr.String(), "missing data file") {
+		t.Error("Expected 2 'missing data file', got ", code, stderr.String())
+	}
+}
+
+func TestRunLockedSecret(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	output := models.Output{
+		Data: []models.MakeRequestData{
+			{Method: "GET", URL: "{host}/tickets", ProjectName: "Jira", Alias: "List tickets",
+				Auth: models.Auth{Type: models.AuthBearer, Token: "{token}"}},
+		},
+		Context: models.Context{
+			Env: map[string][]models.ContextVariable{
+				"prod": {{Variable: "{host}", Value: server.URL}, {Variable: "{token}", Secret: true}},
+			},
+		},
+	}
+	data, _ := json.Marshal(output)
+	filename := filepath.Join(t.TempDir(), "data.json")
+	if err := ioutil.WriteFile(filename, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// no passphrase => the secrets are locked
+	t.Setenv(PassphraseEnv, "")
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{filename, "--project", "Jira", "--alias", "List tickets", "--env", "prod"}, &stdout, &stderr)
+	if code != ExitUsage || called || !strings.Contains(stderr.String(), "locked") {
+		t.Error("Expected 2 'locked' without call, got ", code, called, stderr.String())
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	for statusCode, expected := range map[int]int{200: 0, 302: 0, 400: 4, 404: 4, 500: 5, 503: 5} {
+		if actual := ExitCode(statusCode); actual != expected {
+			t.Error("Expected ", expected, ", got ", actual)
+		}
+	}
+}
+
+func TestRunProject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("tickets"))
+	}))
+	defer server.Close()
+
+	filename := writeDataFile(t, server.URL)
+	junit := filepath.Join(t.TempDir(), "report.xml")
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{filename, "--project", "Jira", "--env", "prod", "--junit", junit}, &stdout, &stderr)
+	if code != ExitError || !strings.Contains(stderr.String(), "1 passed, 1 failed, 0 skipped") {
+		t.Error("Expected 1 '1 passed, 1 failed, 0 skipped', got ", code, stderr.String())
+	}
+
+	data, err := ioutil.ReadFile(junit)
+	if err != nil || !strings.Contains(string(data), `<testsuite name="Jira (prod)" tests="2" failures="1"`) {
+		t.Error("Expected JUnit XML report, got ", string(data), err)
+	}
+
+	stderr.Reset()
+	Run([]string{filename, "--project", "Jira", "--env", "prod", "--stop-on-failure"}, &stdout, &stderr)
+	// the requests are run in the tree order ("Missing" first)
+	if !strings.Contains(stderr.String(), "0 passed, 1 failed, 1 skipped") {
+		t.Error("Expected '0 passed, 1 failed, 1 skipped', got ", stderr.String())
+	}
+}
+
+func TestRunAssertions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"total": 2}`))
+	}))
+	defer server.Close()
+
+	output := models.Output{
+		Data: []models.MakeRequestData{
+			{Method: "GET", URL: types.URL(server.URL), Alias: "Count", Assertions: []models.Assertion{
+				models.NewAssertion(models.AssertStatusEquals, "", "200"),
+				models.NewAssertion(models.AssertJSONPathEquals, "$.total", "3"),
+			}},
+		},
+	}
+	data, _ := json.Marshal(output)
+	filename := filepath.Join(t.TempDir(), "data.json")
+	ioutil.WriteFile(filename, data, 0644)
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{filename, "--alias", "Count", "--json"}, &stdout, &stderr)
+
+	var result runResult
+	json.Unmarshal(stdout.Bytes(), &result)
+	if code != ExitError || len(result.Assertions) != 2 || !result.Assertions[0].Passed || result.Assertions[1].Passed {
+		t.Error("Expected 1 with a failed assertion, got ", code, stdout.String())
+	}
+}
+
+func TestRunProjectChaining(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			w.Write([]byte(`{"access_token": "secret"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	output := models.Output{
+		// the requests of a project are run in the tree order (the last one first)
+		Data: []models.MakeRequestData{
+			{Method: "GET", URL: types.URL(server.URL + "/tickets"), ProjectName: "Jira", Alias: "List tickets",
+				MapRequestHeaderKeyValue: core.StringMap{"Authorization": "Bearer {token}"}},
+			{Method: "POST", URL: types.URL(server.URL + "/login"), ProjectName: "Jira", Alias: "Login",
+				Extractions: []models.Extraction{models.NewExtraction(models.ExtractJSONPath, "$.access_token", "token")}},
+		},
+	}
+	data, _ := json.Marshal(output)
+	filename := filepath.Join(t.TempDir(), "data.json")
+	ioutil.WriteFile(filename, data, 0644)
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{filename, "--project", "Jira"}, &stdout, &stderr); code != ExitOK {
+		t.Error("Expected 0, got ", code, stdout.String(), stderr.String())
+	}
+}
@@ -19,6 +19,7 @@ type TreeCpnt struct {
 
 	refreshMDRView func(it models.MakeRequestData)
 	switchToPage   func(page string)
+	runProject     func(projectName string)
 }
 
 // NewTreeCpnt returns a new TreeCpnt struct
@@ -36,13 +37,14 @@ func NewTreeCpnt(app *tview.Application, ctx *models.AppCtx) *TreeCpnt {
 }
 
 // Make makes the tree (home made) component
-func (cpnt *TreeCpnt) Make(refreshMDRView func(it models.MakeRequestData), switchToPage func(page string)) *tview.Flex {
+func (cpnt *TreeCpnt) Make(refreshMDRView func(it models.MakeRequestData), switchToPage func(page string), runProject func(projectName string)) *tview.Flex {
 	cpnt.RootPrmt = tview.NewFlex().SetDirection(tview.FlexRow)
 	cpnt.RootPrmt.SetBorder(false)
 	cpnt.RootPrmt.SetBorderPadding(0, 0, 0, 0)
 
 	cpnt.refreshMDRView = refreshMDRView
 	cpnt.switchToPage = switchToPage
+	cpnt.runProject = runProject
 
 	titleTextView := tview.NewTextView()
 	cpnt.RootPrmt.AddItem(titleTextView, 0, 0, false)
@@ -78,9 +80,20 @@ func (cpnt *TreeCpnt) pressKeyUp() {
 	cpnt.selectNode(previousIndex, cpnt.treeIndex)
 }
 
+// pressKeyEnter runs all the requests of the selected project node
+func (cpnt *TreeCpnt) pressKeyEnter() {
+	if node, exists := cpnt.nodes[cpnt.treeIndex]; exists && node.method == "" && cpnt.runProject != nil {
+		cpnt.runProject(node.projectName)
+	}
+}
+
 func (cpnt *TreeCpnt) selectNode(previousIndex int, index int) {
 	node := cpnt.refreshNodeText(previousIndex, index)
-	it, error := cpnt.AppCtx.GetOutput().Find(node.method.String(), node.url.String())
+	projectName := node.projectName
+	if projectName == "." {
+		projectName = ""
+	}
+	it, error := cpnt.AppCtx.GetOutput().FindRequest(models.MakeRequestData{Method: node.method, URL: node.url, ProjectName: projectName, Alias: node.alias})
 	if error == nil {
 		cpnt.refreshMDRView(it)
 		cpnt.switchToPage("RequestExpertModeViewPage")
@@ -95,7 +108,7 @@ func (cpnt *TreeCpnt) refreshNodeText(previousIndex int, index int) TreeCpntNode
 
 	// update current code with the "> my text"
 	node := cpnt.nodes[index]
-	node.textView.SetText(string(9658) + " " + node.label)
+	node.textView.SetText(string(rune(9658)) + " " + node.label)
 
 	return node
 }
@@ -111,6 +124,8 @@ func (cpnt *TreeCpnt) RefreshWithPattern(pattern string, output models.Output) {
 				cpnt.pressKeyDown()
 			case tcell.KeyUp:
 				cpnt.pressKeyUp()
+			case tcell.KeyEnter:
+				cpnt.pressKeyEnter()
 			}
 			return event
 		})
@@ -130,7 +145,7 @@ func (cpnt *TreeCpnt) RefreshWithPattern(pattern string, output models.Output) {
 		cpnt.RootPrmt.AddItem(textView, 1, 0, true)
 
 		index++
-		cpnt.nodes[index] = NewTreeCpntNode(textView, parentNodeLabel, "", "")
+		cpnt.nodes[index] = NewTreeCpntNode(textView, parentNodeLabel, projectName, "", "", "")
 		for _, dataAPI := range dataAPIsByProjectName[projectName] {
 			// Add 'request' new child node
 			value := dataAPI.TreeFormat(pattern)
@@ -141,7 +156,7 @@ func (cpnt *TreeCpnt) RefreshWithPattern(pattern string, output models.Output) {
 			cpnt.RootPrmt.AddItem(childNodePrmt, 1, 0, true)
 
 			index++
-			cpnt.nodes[index] = NewTreeCpntNode(childNodePrmt, value, dataAPI.Method, dataAPI.URL)
+			cpnt.nodes[index] = NewTreeCpntNode(childNodePrmt, value, projectName, dataAPI.Method, dataAPI.URL, dataAPI.Alias)
 		}
 	}
 }
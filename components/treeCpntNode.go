@@ -7,18 +7,22 @@ import (
 
 // TreeCpntNode contains data for tree cpnt
 type TreeCpntNode struct {
-	textView *tview.TextView
-	label    string
-	method   types.Method
-	url      types.URL
+	textView    *tview.TextView
+	label       string
+	projectName string
+	method      types.Method
+	url         types.URL
+	alias       string
 }
 
 // NewTreeCpntNode creates new TreeCpntNode struct
-func NewTreeCpntNode(prmt *tview.TextView, label string, method types.Method, url types.URL) TreeCpntNode {
+func NewTreeCpntNode(prmt *tview.TextView, label string, projectName string, method types.Method, url types.URL, alias string) TreeCpntNode {
 	return TreeCpntNode{
-		textView: prmt,
-		label:    label,
-		method:   method,
-		url:      url,
+		textView:    prmt,
+		label:       label,
+		projectName: projectName,
+		method:      method,
+		url:         url,
+		alias:       alias,
 	}
 }
@@ -1,16 +1,22 @@
 package controllers
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"os"
+	"reflect"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joakim-ribier/gttp/actions"
-	"github.com/joakim-ribier/gttp/httpclient"
+	"github.com/joakim-ribier/gttp/converters"
 	"github.com/joakim-ribier/gttp/models"
 	"github.com/joakim-ribier/gttp/models/types"
 	"github.com/joakim-ribier/gttp/services"
+	"github.com/joakim-ribier/gttp/utils"
 	"github.com/joakim-ribier/gttp/views"
 	"github.com/rivo/tview"
 )
@@ -25,25 +31,38 @@ type MakeRequestController struct {
 
 	// services
 	AppDataService *services.ApplicationDataService
+	HistoryService *services.HistoryService
+	RequestService *services.RequestService
 
 	// models
 	AppCtx *models.AppCtx
 
 	// lib
 	App *tview.Application
+	Log func(message string, mode string)
+
+	// running request
+	cancel    context.CancelFunc
+	execution int
 }
 
 func NewMakeRequestController(
 	app *tview.Application,
 	appDataService *services.ApplicationDataService,
+	historyService *services.HistoryService,
+	requestService *services.RequestService,
 	ctx *models.AppCtx,
-	action *actions.MakeRequestAction) *MakeRequestController {
+	action *actions.MakeRequestAction,
+	log func(message string, mode string)) *MakeRequestController {
 
 	return &MakeRequestController{
 		App:            app,
+		Log:            log,
 		AppCtx:         ctx,
 		View:           nil,
 		AppDataService: appDataService,
+		HistoryService: historyService,
+		RequestService: requestService,
 		Action:         action,
 	}
 }
@@ -53,15 +72,16 @@ func (c *MakeRequestController) Draw() tview.Primitive {
 	if c.View == nil {
 		c.View = views.NewMakeRequestView(c.App, c.AppCtx, c.saveC, c.deleteC)
 	}
-	c.View.InitView(c.Execute, c.ExpertMode, c.New)
+	c.View.InitView(c.Execute, c.ExpertMode, c.New, c.ImportCurl)
 	return c.View.RootPrmt
 }
 
-// saveC reloads file and saves/updates the current request.
-func (c *MakeRequestController) saveC(callback func()) {
+// saveC reloads file and saves/updates the current request (renamed from the @previous project name/alias).
+func (c *MakeRequestController) saveC(previous models.MakeRequestData, callback func()) {
 	// reload the data from file to save only the current updated request
 	output := c.AppDataService.Load()
 
+	output.Remove(previous)
 	output.AddOrReplace(c.AppCtx.GetMDR())
 
 	c.AppDataService.Save(output)
@@ -84,39 +104,149 @@ func (c *MakeRequestController) deleteC(callback func()) {
 	callback()
 }
 
-// Execute calls the request and display the response.
+// Execute calls the request in background and display the response.
 func (c *MakeRequestController) Execute() {
+	// Only one request at a time, abort the previous one
+	c.Cancel()
+
 	makeRequestData := c.AppCtx.GetMDR()
 	prefix := "[" + strconv.Itoa(rand.Intn(100)) + "] "
 
 	// Get current context to replace all variables
 	_, currentContext := c.View.GetContext()
-	currentContextValues := c.AppCtx.GetOutput().Context.GetAllKeyValue(currentContext)
-
-	URL := types.URL(c.View.GetURL()).
-		ReplaceContext(makeRequestData.MapRequestHeaderKeyValue).
-		ReplaceContext(currentContextValues)
-
-	method := makeRequestData.Method
-	contentType := makeRequestData.ContentType
-	body := []byte(makeRequestData.Body)
-	httpHeaderValues := makeRequestData.GetHTTPHeaderValues().ReplaceContext(currentContextValues)
 
-	HTTPClient, error := httpclient.Call(method, URL, contentType, body, httpHeaderValues, c.Action.DisplayErrorRequest)
+	makeRequestData.URL = types.URL(c.View.GetURL())
+	request, error := c.RequestService.Resolve(makeRequestData, currentContext)
 	if error != nil {
-		c.AppCtx.PrintInfo(prefix + makeRequestData.ToLog(URL))
+		// the request is not sent with the {variable} of a secret
 		c.AppCtx.PrintError(prefix + fmt.Sprint(error))
-
 		c.Action.DisplayErrorRequest(fmt.Sprint(error), "error")
-	} else {
-		c.AppCtx.PrintInfo(prefix + makeRequestData.ToLog(URL))
+		return
+	}
+	URL := request.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.execution++
+	execution := c.execution
+
+	// Logger called from the request goroutine, the view must be updated from the UI goroutine
+	logger := func(message string, mode string) {
+		c.App.QueueUpdateDraw(func() {
+			c.Action.DisplayErrorRequest(c.RequestService.Mask(currentContext, message), mode)
+		})
+	}
+
+	// Hide the assertions & extractions of the previous request
+	c.Action.DisplayAssertions(nil)
+	c.Action.DisplayExtractions(nil)
+
+	start := time.Now()
+	done := make(chan struct{})
+
+	go c.progress(start, done)
+
+	go func() {
+		HTTPClient, error := c.RequestService.Call(ctx, request, logger)
+		close(done)
+
+		c.App.QueueUpdateDraw(func() {
+			if execution != c.execution {
+				// request replaced by a new one, nothing to display
+				return
+			}
+			c.cancel = nil
+			cancel()
+
+			duration := time.Since(start)
+			// the secret values are not saved in the history (or the log)
+			requestHeaders := map[string]string{"Content-Type": request.ContentType}
+			for key, value := range request.Headers {
+				requestHeaders[key] = c.RequestService.Mask(currentContext, value)
+			}
+			maskedURL := types.URL(c.RequestService.Mask(currentContext, URL.String()))
+			entry := models.NewHistoryEntry(makeRequestData, currentContext, maskedURL.String(), requestHeaders, duration, 0, "", nil)
+
+			c.AppCtx.PrintInfo(prefix + makeRequestData.ToLog(maskedURL))
+			if errors.Is(error, context.Canceled) {
+				c.AppCtx.PrintInfo(prefix + "cancelled")
+
+				entry.Error = "cancelled"
+				c.Action.DisplayCancelled(duration)
+			} else if error != nil {
+				c.AppCtx.PrintError(prefix + fmt.Sprint(error))
+
+				entry.Error = fmt.Sprint(error)
+				c.Action.DisplayErrorRequest(fmt.Sprint(error), "error")
+			} else {
+				response := fmt.Sprintf("%+s", HTTPClient.Body)
+				if logRequestOn {
+					c.AppCtx.PrintInfo(prefix + response)
+				}
+
+				entry = models.NewHistoryEntry(makeRequestData, currentContext, maskedURL.String(), requestHeaders, duration,
+					HTTPClient.Response.Response.StatusCode, HTTPClient.Response.Status, HTTPClient.Body)
+				c.Action.DisplayResponse(HTTPClient, response)
+				c.Action.DisplayAssertions(c.RequestService.Assert(request, HTTPClient, duration))
+
+				if len(request.Extractions) > 0 {
+					// Save the extracted values in the context variables of the execution context
+					executionContext := c.AppCtx.GetOutput().Context.Copy()
+					results := c.RequestService.Extract(request, HTTPClient, duration, &executionContext, currentContext)
+
+					c.AppCtx.UpdateContext(executionContext)
+					c.Action.DisplayExtractions(results)
+				}
+			}
+
+			c.HistoryService.Add(entry)
+			c.AppCtx.RefreshViews("history")
+		})
+	}()
+}
+
+// Cancel cancels the running request (if exists).
+func (c *MakeRequestController) Cancel() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+}
+
+// progress displays the elapsed time of the running request until @done is closed.
+func (c *MakeRequestController) progress(start time.Time, done chan struct{}) {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
 
-		response := fmt.Sprintf("%+s", HTTPClient.Body)
-		if logRequestOn {
-			c.AppCtx.PrintInfo(prefix + response)
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			c.App.QueueUpdateDraw(func() {
+				select {
+				case <-done:
+				default:
+					c.Action.DisplayProgress(time.Since(start))
+				}
+			})
 		}
+	}
+}
 
-		c.Action.DisplayResponse(HTTPClient, response)
+// SaveResponseFilter remembers the response filter @expression of the current request (in the data file if the request is saved).
+func (c *MakeRequestController) SaveResponseFilter(expression string) {
+	makeRequestData := c.AppCtx.GetMDR()
+	if makeRequestData.ResponseFilter == expression {
+		return
+	}
+	makeRequestData.ResponseFilter = expression
+	c.AppCtx.UpdateMDR(makeRequestData)
+
+	// reload the data from file to update only the filter of the current request
+	output := c.AppDataService.Load()
+	if output.UpdateResponseFilter(makeRequestData, expression) {
+		c.AppDataService.Save(output)
+		c.AppCtx.RefreshViews("tree")
 	}
 }
 
@@ -140,3 +270,49 @@ func (c *MakeRequestController) New() {
 func (c *MakeRequestController) ExpertMode() {
 	c.AppCtx.SwitchView("ExpertRequestView")
 }
+
+// ImportCurl imports the curl command from the clipboard and displays it in expert request mode view
+func (c *MakeRequestController) ImportCurl() {
+	command, error := utils.ReadFromClipboard()
+	if error != nil {
+		c.Log("Error to read data from clipboard.", "error")
+		return
+	}
+
+	makeRequestData, warnings, error := converters.ParseCurl(strings.TrimSpace(command))
+	if error != nil {
+		c.Log("Impossible to import curl command: "+error.Error(), "error")
+		return
+	}
+
+	// the current request is replaced, the unsaved changes are lost
+	if c.hasUnsavedChanges() {
+		c.View.DisplayConfirmView("The current request is not saved, do you want to replace it?", " Import curl ", func() {
+			c.importCurl(makeRequestData, warnings)
+		})
+		return
+	}
+	c.importCurl(makeRequestData, warnings)
+}
+
+func (c *MakeRequestController) importCurl(makeRequestData models.MakeRequestData, warnings []string) {
+	c.AppCtx.UpdateMDR(makeRequestData)
+	c.AppCtx.RefreshViews("request")
+	c.ExpertMode()
+
+	if len(warnings) > 0 {
+		c.Log("Curl command imported ("+strings.Join(warnings, ", ")+"), press Ctrl+S to save it.", "warn")
+	} else {
+		c.Log("Curl command imported, press Ctrl+S to save it.", "info")
+	}
+}
+
+// hasUnsavedChanges returns true if the current request is neither empty nor equal to its saved version
+func (c *MakeRequestController) hasUnsavedChanges() bool {
+	makeRequestData := c.AppCtx.GetMDR().Normalized()
+	if reflect.DeepEqual(makeRequestData, models.EmptyMakeRequestData().Normalized()) {
+		return false
+	}
+	saved, error := c.AppDataService.Load().FindRequest(makeRequestData)
+	return error != nil || !reflect.DeepEqual(makeRequestData, saved.Normalized())
+}
@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/joakim-ribier/gttp/models"
+	"github.com/joakim-ribier/gttp/services"
+	"github.com/joakim-ribier/gttp/views"
+	"github.com/rivo/tview"
+)
+
+type RunnerController struct {
+	View *views.RunnerView
+
+	// services
+	RunnerService *services.RunnerService
+
+	// models
+	AppCtx *models.AppCtx
+
+	// lib
+	App *tview.Application
+	Log func(message string, mode string)
+
+	// running project
+	cancel context.CancelFunc
+	runner *models.Runner
+}
+
+func NewRunnerController(
+	app *tview.Application,
+	runnerService *services.RunnerService,
+	ctx *models.AppCtx,
+	log func(message string, mode string)) *RunnerController {
+
+	return &RunnerController{
+		App:           app,
+		Log:           log,
+		AppCtx:        ctx,
+		View:          nil,
+		RunnerService: runnerService,
+	}
+}
+
+// Draw contructs and initializes the view.
+func (c *RunnerController) Draw() tview.Primitive {
+	if c.View == nil {
+		c.View = views.NewRunnerView(c.App, c.AppCtx, c.Run, c.Stop, c.Export)
+		c.View.InitView()
+	}
+	return c.View.ParentPrmt
+}
+
+// RunProject selects the @projectName in the view and runs it.
+func (c *RunnerController) RunProject(projectName string) {
+	c.View.SetProject(projectName)
+	c.View.RunProject()
+}
+
+// Run executes in background all the requests of the @projectName and displays the results.
+func (c *RunnerController) Run(projectName string, env string, stopOnFailure bool) {
+	if c.cancel != nil {
+		c.Log("A project is already running, stop it before running a new one.", "warn")
+		return
+	}
+	if projectName == "" {
+		c.Log("No project to run.", "warn")
+		return
+	}
+
+	// snapshot taken here, the output must not be read from the run goroutine
+	output := c.AppCtx.GetOutput()
+
+	c.runner = nil
+	c.View.Start(models.NewRunner(projectName, env, c.RunnerService.Requests(projectName)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	c.AppCtx.PrintInfo("Runner.Run '" + projectName + "' (" + env + ")")
+
+	go func() {
+		runner := c.RunnerService.Run(ctx, output, projectName, env, stopOnFailure, func(index int, result models.RunnerResult) {
+			c.App.QueueUpdateDraw(func() {
+				c.View.DisplayResult(index, result)
+			})
+		})
+
+		c.App.QueueUpdateDraw(func() {
+			c.cancel = nil
+			cancel()
+
+			c.runner = &runner
+			c.View.Finish(runner)
+
+			// Save the values extracted during the run in the current context variables
+			if runner.Extracted() {
+				context := c.AppCtx.GetOutput().Context.Copy()
+				runner.MergeExtracted(&context)
+				c.AppCtx.UpdateContext(context)
+			}
+
+			if _, failed, _ := runner.Count(); failed > 0 {
+				c.Log("Project '"+projectName+"' executed with failure(s).", "error")
+			} else {
+				c.Log("Project '"+projectName+"' executed.", "info")
+			}
+		})
+	}()
+}
+
+// Stop stops the running project (if exists), the remaining requests are skipped.
+func (c *RunnerController) Stop() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+}
+
+// Export writes the results of the last run as JUnit XML in the @filename.
+func (c *RunnerController) Export(filename string) error {
+	if c.cancel != nil {
+		return errors.New("the project is still running")
+	}
+	if c.runner == nil {
+		return errors.New("no result to export, run a project first")
+	}
+	return c.RunnerService.ExportJUnit(*c.runner, filename)
+}
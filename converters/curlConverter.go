@@ -0,0 +1,333 @@
+package converters
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/url"
+	"strings"
+
+	"github.com/joakim-ribier/gttp/core"
+	"github.com/joakim-ribier/gttp/models"
+	"github.com/joakim-ribier/gttp/models/types"
+)
+
+// multipartBoundary is the boundary used to build the multipart body of the imported curl forms
+const multipartBoundary = "gttp-form-boundary"
+
+// curlIgnoredOptions lists the curl options (without value) which have no meaning for gttp
+var curlIgnoredOptions = core.StringSlice{
+	"-s", "--silent", "-S", "--show-error", "-v", "--verbose", "-L", "--location", "-k", "--insecure",
+	"-i", "--include", "--compressed", "-f", "--fail", "-#", "--progress-bar", "-N", "--no-buffer",
+}
+
+// curlIgnoredOptionsWithValue lists the curl options (with value) which have no meaning for gttp
+var curlIgnoredOptionsWithValue = core.StringSlice{
+	"-o", "--output", "-m", "--max-time", "--connect-timeout", "-w", "--write-out", "--retry", "-x", "--proxy",
+}
+
+// ParseCurl parses a curl command line to a MakeRequestData,
+// it returns also the list of the curl options which are not supported (ignored).
+func ParseCurl(command string) (models.MakeRequestData, []string, error) {
+	mrd := models.EmptyMakeRequestData()
+	mrd.Method = ""
+	mrd.ContentType = ""
+
+	args, err := splitShellArgs(command)
+	if err != nil {
+		return mrd, nil, err
+	}
+	if len(args) == 0 || args[0] != "curl" {
+		return mrd, nil, errors.New("not a curl command")
+	}
+
+	var warnings []string
+	var data []string
+	var form []string
+	getData := false
+	fileData := false
+
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+
+		// Option with the value attached ("-XPOST", "--request=POST")
+		name, value, hasValue := arg, "", false
+		if strings.HasPrefix(arg, "--") {
+			if index := strings.Index(arg, "="); index != -1 {
+				name, value, hasValue = arg[:index], arg[index+1:], true
+			}
+		} else if strings.HasPrefix(arg, "-") && len(arg) > 2 && strings.Contains("XHduFAebo", arg[1:2]) {
+			name, value, hasValue = arg[:2], arg[2:], true
+		}
+
+		nextValue := func() (string, error) {
+			if hasValue {
+				return value, nil
+			}
+			if i+1 >= len(args) {
+				return "", errors.New("missing value for the option '" + name + "'")
+			}
+			i++
+			return args[i], nil
+		}
+
+		switch name {
+		case "-X", "--request":
+			v, err := nextValue()
+			if err != nil {
+				return mrd, warnings, err
+			}
+			// the custom verbs are supported (see types.Method) but not an empty or an invalid one
+			if err := types.Method(v).Validate(); err != nil {
+				return mrd, warnings, errors.New("invalid method for the option '" + name + "': " + err.Error())
+			}
+			mrd.Method = types.Method(v)
+		case "-H", "--header":
+			v, err := nextValue()
+			if err != nil {
+				return mrd, warnings, err
+			}
+			addCurlHeader(&mrd, v)
+		case "-A", "--user-agent":
+			v, err := nextValue()
+			if err != nil {
+				return mrd, warnings, err
+			}
+			mrd = mrd.SetHeader("User-Agent", v, true)
+		case "-e", "--referer":
+			v, err := nextValue()
+			if err != nil {
+				return mrd, warnings, err
+			}
+			mrd = mrd.SetHeader("Referer", v, true)
+		case "-b", "--cookie":
+			v, err := nextValue()
+			if err != nil {
+				return mrd, warnings, err
+			}
+			mrd = mrd.SetHeader("Cookie", v, true)
+		case "-d", "--data", "--data-raw", "--data-binary", "--data-ascii":
+			v, err := nextValue()
+			if err != nil {
+				return mrd, warnings, err
+			}
+			if strings.HasPrefix(v, "@") && name != "--data-raw" {
+				// the file is not read, the body is left empty
+				warnings = append(warnings, "file data '"+v+"' not imported")
+				fileData = true
+				continue
+			}
+			data = append(data, v)
+		case "--data-urlencode":
+			v, err := nextValue()
+			if err != nil {
+				return mrd, warnings, err
+			}
+			data = append(data, urlEncodeCurlData(v))
+		case "-F", "--form":
+			v, err := nextValue()
+			if err != nil {
+				return mrd, warnings, err
+			}
+			form = append(form, v)
+		case "-u", "--user":
+			v, err := nextValue()
+			if err != nil {
+				return mrd, warnings, err
+			}
+			credentials := strings.SplitN(v, ":", 2)
+			mrd = mrd.SetHeader("Authorization", basicAuthorization(credentials[0], strings.Join(credentials[1:], "")), true)
+		case "--url":
+			v, err := nextValue()
+			if err != nil {
+				return mrd, warnings, err
+			}
+			mrd.URL = types.URL(v)
+		case "-G", "--get":
+			getData = true
+		case "-I", "--head":
+			mrd.Method = "HEAD"
+		default:
+			if !strings.HasPrefix(arg, "-") {
+				mrd.URL = types.URL(arg)
+			} else if curlIgnoredOptions.GetIndex(name) != -1 || isCurlIgnoredShortOptions(arg) {
+				continue
+			} else if curlIgnoredOptionsWithValue.GetIndex(name) != -1 {
+				if _, err := nextValue(); err != nil {
+					return mrd, warnings, err
+				}
+				warnings = append(warnings, "option '"+name+"' ignored")
+			} else {
+				warnings = append(warnings, "option '"+name+"' not supported")
+			}
+		}
+	}
+
+	if mrd.URL == "" {
+		return mrd, warnings, errors.New("no url found in the curl command")
+	}
+
+	if len(data) > 0 {
+		if getData {
+			separator := "?"
+			if strings.Contains(mrd.URL.String(), "?") {
+				separator = "&"
+			}
+			mrd.URL = types.URL(mrd.URL.String() + separator + strings.Join(data, "&"))
+		} else {
+			mrd.Body = strings.Join(data, "&")
+			if mrd.ContentType == "" && !json.Valid([]byte(mrd.Body)) {
+				mrd.ContentType = "application/x-www-form-urlencoded"
+			}
+		}
+	}
+	if mrd.ContentType == "" {
+		mrd.ContentType = "application/json"
+	}
+
+	if len(form) > 0 {
+		body, formWarnings := buildCurlMultipartBody(form)
+		mrd.Body = body
+		mrd.ContentType = "multipart/form-data; boundary=" + multipartBoundary
+		warnings = append(warnings, formWarnings...)
+	}
+
+	if mrd.Method == "" {
+		if ((len(data) > 0 || fileData) && !getData) || len(form) > 0 {
+			mrd.Method = "POST"
+		} else {
+			mrd.Method = "GET"
+		}
+	}
+
+	return mrd, warnings, nil
+}
+
+// isCurlIgnoredShortOptions returns true if @arg is a group of ignored short options ("-sSL")
+func isCurlIgnoredShortOptions(arg string) bool {
+	if strings.HasPrefix(arg, "--") || len(arg) < 3 {
+		return false
+	}
+	for _, r := range arg[1:] {
+		if curlIgnoredOptions.GetIndex("-"+string(r)) == -1 {
+			return false
+		}
+	}
+	return true
+}
+
+// addCurlHeader adds "Key: Value" header, the "Content-Type" header is stored as request content type
+func addCurlHeader(mrd *models.MakeRequestData, header string) {
+	index := strings.Index(header, ":")
+	if index == -1 {
+		return
+	}
+	key := strings.TrimSpace(header[:index])
+	value := strings.TrimSpace(header[index+1:])
+	if strings.EqualFold(key, "Content-Type") {
+		mrd.ContentType = value
+	} else {
+		*mrd = mrd.SetHeader(key, value, true)
+	}
+}
+
+// urlEncodeCurlData encodes the value like curl --data-urlencode ("content", "=content", "name=content")
+func urlEncodeCurlData(value string) string {
+	index := strings.Index(value, "=")
+	switch {
+	case index == -1:
+		return url.QueryEscape(value)
+	case index == 0:
+		return url.QueryEscape(value[1:])
+	default:
+		return value[:index] + "=" + url.QueryEscape(value[index+1:])
+	}
+}
+
+// buildCurlMultipartBody builds a multipart body from the curl -F values ("name=value", "name=@file")
+func buildCurlMultipartBody(fields []string) (string, []string) {
+	var warnings []string
+	var buf bytes.Buffer
+
+	writer := multipart.NewWriter(&buf)
+	writer.SetBoundary(multipartBoundary)
+	for _, field := range fields {
+		index := strings.Index(field, "=")
+		if index == -1 {
+			warnings = append(warnings, "form field '"+field+"' ignored")
+			continue
+		}
+		name, value := field[:index], field[index+1:]
+		if strings.HasPrefix(value, "@") || strings.HasPrefix(value, "<") {
+			warnings = append(warnings, "form file '"+value+"' not imported")
+		}
+		writer.WriteField(name, value)
+	}
+	writer.Close()
+
+	return buf.String(), warnings
+}
+
+// splitShellArgs splits a command line like a POSIX shell (quotes, escapes and line continuations)
+func splitShellArgs(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+
+	runes := []rune(command)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\':
+			if i+1 < len(runes) {
+				i++
+				if runes[i] != '\n' && runes[i] != '\r' {
+					current.WriteRune(runes[i])
+					inArg = true
+				} else if runes[i] == '\r' && i+1 < len(runes) && runes[i+1] == '\n' {
+					i++
+				}
+			}
+		case r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != '\'' {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, errors.New("unterminated single quote")
+			}
+			current.WriteString(string(runes[i+1 : end]))
+			inArg = true
+			i = end
+		case r == '"':
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) && strings.ContainsRune("\"\\$`\n", runes[i+1]) {
+					i++
+					if runes[i] == '\n' {
+						continue
+					}
+				}
+				current.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, errors.New("unterminated double quote")
+			}
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,98 @@
+package converters
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Test 'ParseCurl' method
+func TestParseCurl(t *testing.T) {
+	command := `curl -X PATCH 'https://api.io/users/1' \
+  -H 'Content-Type: application/json' -H "X-Token: a \"quoted\" value" \
+  --data-raw '{"name": "bob"}' -sSL`
+
+	mrd, warnings, error := ParseCurl(command)
+
+	if error != nil || len(warnings) != 0 {
+		t.Fatal("Expected no error, got ", error, warnings)
+	}
+	if mrd.Method != "PATCH" || mrd.URL != "https://api.io/users/1" {
+		t.Error("Expected 'PATCH https://api.io/users/1', got ", mrd.Method, mrd.URL)
+	}
+	if mrd.ContentType != "application/json" || mrd.Body != `{"name": "bob"}` {
+		t.Error("Expected json body, got ", mrd.ContentType, mrd.Body)
+	}
+	if mrd.MapRequestHeaderKeyValue["X-Token"] != `a "quoted" value` {
+		t.Error("Expected 'X-Token' header, got ", mrd.MapRequestHeaderKeyValue)
+	}
+}
+
+func TestParseCurlDataDefaultsToPost(t *testing.T) {
+	mrd, _, _ := ParseCurl(`curl https://api.io/login -d user=bob --data-urlencode "msg=hello world" -u bob:secret`)
+
+	if mrd.Method != "POST" || mrd.ContentType != "application/x-www-form-urlencoded" {
+		t.Error("Expected 'POST' form, got ", mrd.Method, mrd.ContentType)
+	}
+	if mrd.Body != "user=bob&msg=hello+world" {
+		t.Error("Expected 'user=bob&msg=hello+world', got ", mrd.Body)
+	}
+	if mrd.MapRequestHeaderKeyValue["Authorization"] != "Basic Ym9iOnNlY3JldA==" {
+		t.Error("Expected basic authorization, got ", mrd.MapRequestHeaderKeyValue)
+	}
+}
+
+func TestParseCurlForm(t *testing.T) {
+	mrd, warnings, _ := ParseCurl(`curl -F name=bob -F "file=@photo.png" https://api.io/upload`)
+
+	if mrd.Method != "POST" || !strings.HasPrefix(mrd.ContentType, "multipart/form-data; boundary=") {
+		t.Error("Expected 'POST' multipart, got ", mrd.Method, mrd.ContentType)
+	}
+	if !strings.Contains(mrd.Body, "name=\"name\"\r\n\r\nbob") {
+		t.Error("Expected 'name' field, got ", mrd.Body)
+	}
+	if len(warnings) != 1 {
+		t.Error("Expected 1 warning, got ", warnings)
+	}
+}
+
+// Test 'ParseCurl' method with a file as data (not read)
+func TestParseCurlFileData(t *testing.T) {
+	mrd, warnings, error := ParseCurl(`curl -d @payload.json https://api.io/users`)
+
+	if error != nil {
+		t.Fatal("Expected nil, got ", error)
+	}
+	if mrd.Method != "POST" || mrd.Body != "" {
+		t.Error("Expected 'POST' without body, got ", mrd.Method, mrd.Body)
+	}
+	if len(warnings) != 1 {
+		t.Error("Expected 1 warning, got ", warnings)
+	}
+}
+
+// Test 'ParseCurl' method with a custom method
+func TestParseCurlCustomMethod(t *testing.T) {
+	if mrd, _, error := ParseCurl(`curl -X PROPFIND https://api.io/files`); error != nil || mrd.Method != "PROPFIND" {
+		t.Error("Expected 'PROPFIND', got ", mrd.Method, error)
+	}
+}
+
+func TestParseCurlErrors(t *testing.T) {
+	for _, command := range []string{"wget https://api.io", "curl -H 'X: 1'", "curl 'https://api.io", "curl -X", "curl -X '' https://api.io", "curl --request='GE T' https://api.io"} {
+		if _, _, error := ParseCurl(command); error == nil {
+			t.Error("Expected error, got nil for ", command)
+		}
+	}
+}
+
+// Test 'splitShellArgs' method
+func TestSplitShellArgs(t *testing.T) {
+	actual, _ := splitShellArgs(`curl 'a b' "c\"d" e\ f $x \
+ g`)
+	expected := []string{"curl", "a b", `c"d`, "e f", "$x", "g"}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Error("Expected ", expected, ", got ", actual)
+	}
+}
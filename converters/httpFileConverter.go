@@ -0,0 +1,350 @@
+package converters
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/joakim-ribier/gttp/core"
+	"github.com/joakim-ribier/gttp/models"
+	"github.com/joakim-ribier/gttp/models/types"
+)
+
+// HTTPFileEnvFilename is the environment file (JetBrains format) exported next to the .http file
+const HTTPFileEnvFilename = "http-client.env.json"
+
+// Represents the .http file syntax
+const (
+	httpFileSeparator     = "###"
+	httpFileNameTag       = "@name"
+	httpFileProjectTag    = "@project"
+	httpFileDefaultMethod = "GET"
+)
+
+// httpFileVariableRegexp matches the "@variable = value" lines
+var httpFileVariableRegexp = regexp.MustCompile(`^@([A-Za-z0-9_\-\.]+)\s*=\s*(.*)$`)
+
+// httpFileRequestLineRegexp matches the "METHOD url HTTP/version" lines
+var httpFileRequestLineRegexp = regexp.MustCompile(`^([A-Za-z]+)\s+(\S+)(\s+HTTP/[0-9.]+)?$`)
+
+// httpFileDisabledHeaderRegexp matches the "# Key: value" lines of the headers (disabled header)
+var httpFileDisabledHeaderRegexp = regexp.MustCompile(`^(?:#|//)\s*([A-Za-z0-9!#$%&'*+.^_|~\-]+):\s*(.*)$`)
+
+// httpFileDisabledQueryParamRegexp matches the "# ?key=value" lines (disabled query param)
+var httpFileDisabledQueryParamRegexp = regexp.MustCompile(`^(?:#|//)\s*[?&](.+)$`)
+
+// gttpVariableRegexp matches the gttp "{variable}" placeholders
+var gttpVariableRegexp = regexp.MustCompile(`\{([A-Za-z0-9_\-\.]+)\}`)
+
+// ExportHTTPFile converts the requests of the @projectName (or all if empty) to a .http file,
+// it returns also the environments as JetBrains "http-client.env.json" content and the list of the items which are not exported.
+func ExportHTTPFile(output models.Output, projectName string) (string, string, []string) {
+	var warnings []string
+
+	variables := make(map[string]bool)
+	for _, env := range output.Context.Env {
+		for _, variable := range env {
+			variables[variable.Variable] = true
+		}
+	}
+
+	toHTTPFileVariables := func(value string) string {
+		return gttpVariableRegexp.ReplaceAllStringFunc(value, func(match string) string {
+			if variables[match] {
+				return "{" + match + "}"
+			}
+			return match
+		})
+	}
+
+	var sb strings.Builder
+	sortedProjectName, dataAPIsByProjectName := output.SortDataAPIsByProjectName()
+	for _, name := range sortedProjectName {
+		if projectName != "" && name != projectName {
+			continue
+		}
+		for _, mrd := range dataAPIsByProjectName[name] {
+			sb.WriteString(httpFileSeparator + " " + mrd.Alias + "\n")
+			if mrd.ProjectName != "" {
+				sb.WriteString("# " + httpFileProjectTag + " " + mrd.ProjectName + "\n")
+			}
+
+			// the path params are written inline, the "@variable" lines are global to the file
+			mrd = mrd.Normalized()
+			headers, url := exportHTTPFileAuth(mrd, &warnings)
+
+			sb.WriteString(mrd.Method.String() + " " + toHTTPFileVariables(url) + "\n")
+			// the disabled query params are kept as comments
+			for _, param := range mrd.QueryParams {
+				if !param.Enabled {
+					sb.WriteString("# ?" + toHTTPFileVariables(param.String()) + "\n")
+				}
+			}
+			if mrd.ContentType != "" {
+				sb.WriteString("Content-Type: " + mrd.ContentType + "\n")
+			}
+			for _, key := range headers.ToSortedKeys() {
+				// the disabled headers are kept as comments
+				if !mrd.IsHeaderEnabled(key) {
+					sb.WriteString("# ")
+				}
+				sb.WriteString(key + ": " + toHTTPFileVariables(headers[key]) + "\n")
+			}
+			if mrd.Body != "" {
+				sb.WriteString("\n" + toHTTPFileVariables(mrd.Body) + "\n")
+			}
+			sb.WriteString("\n")
+
+			// the gttp settings have no equivalent in a .http file
+			if mrd.Timeout != (models.Timeout{}) {
+				warnings = append(warnings, "'"+mrd.Alias+"': timeout not exported")
+			}
+			if len(mrd.Assertions) > 0 {
+				warnings = append(warnings, "'"+mrd.Alias+"': assertions not exported")
+			}
+			if len(mrd.Extractions) > 0 {
+				warnings = append(warnings, "'"+mrd.Alias+"': extractions not exported")
+			}
+		}
+	}
+
+	envs := make(map[string]map[string]string)
+	for env, values := range output.Context.Env {
+		envs[env] = make(map[string]string)
+		for _, value := range values {
+			// the secret values are not in the context
+			if !value.Secret {
+				envs[env][strings.Trim(value.Variable, "{}")] = value.Value
+			}
+		}
+	}
+	envData, _ := json.MarshalIndent(envs, "", "  ")
+
+	return sb.String(), string(envData), warnings
+}
+
+// exportHTTPFileAuth returns all the headers (enabled & disabled) and the URL of the @mrd with its authentication applied,
+// the Basic credentials with {variable} are written "user:password" (encoded by the .http clients)
+func exportHTTPFileAuth(mrd models.MakeRequestData, warnings *[]string) (core.StringMap, string) {
+	headers := mrd.GetHeaders()
+	url := mrd.URL.ReplaceContext(mrd.PathParams).String()
+
+	auth := mrd.Auth
+	switch {
+	case auth.IsEmpty():
+	case auth.Type == models.AuthDigest:
+		*warnings = append(*warnings, "'"+mrd.Alias+"': digest authentication not exported")
+	case auth.Type == models.AuthBasic && gttpVariableRegexp.MatchString(auth.Username+auth.Password):
+		headers["Authorization"] = "Basic " + auth.Username + ":" + auth.Password
+	case auth.Type == models.AuthAPIKeyQuery:
+		// not escaped to keep the {variable}
+		separator := "?"
+		if strings.Contains(url, "?") {
+			separator = "&"
+		}
+		url += separator + auth.Key + "=" + auth.Token
+	default:
+		for key, value := range (models.ResolvedRequest{Auth: auth}).WithAuthHeaders().Headers {
+			headers[key] = value
+		}
+	}
+	return headers, url
+}
+
+// MergeHTTPFileEnv merges the @exported environments into the @existing JetBrains environment file content,
+// the exported variables replace the existing ones, the other environments & variables are kept.
+func MergeHTTPFileEnv(existing []byte, exported []byte) ([]byte, error) {
+	envs := make(map[string]map[string]interface{})
+	if len(strings.TrimSpace(string(existing))) > 0 {
+		if err := json.Unmarshal(existing, &envs); err != nil {
+			return nil, errors.New("invalid environment file: " + err.Error())
+		}
+	}
+
+	var exportedEnvs map[string]map[string]interface{}
+	if err := json.Unmarshal(exported, &exportedEnvs); err != nil {
+		return nil, err
+	}
+	for env, values := range exportedEnvs {
+		if envs[env] == nil {
+			envs[env] = make(map[string]interface{})
+		}
+		for key, value := range values {
+			envs[env][key] = value
+		}
+	}
+	return json.MarshalIndent(envs, "", "  ")
+}
+
+// ImportHTTPFile converts a .http file (and its optional JetBrains environment file) to gttp requests,
+// it returns also the list of the unsupported items which are not imported.
+func ImportHTTPFile(data []byte, envData []byte) (models.Output, []string, error) {
+	var output models.Output
+	var warnings []string
+
+	if len(envData) > 0 {
+		var envs map[string]map[string]interface{}
+		if err := json.Unmarshal(envData, &envs); err != nil {
+			return output, nil, errors.New("invalid environment file: " + err.Error())
+		}
+		for env, values := range envs {
+			if env == "$shared" {
+				env = "default"
+			}
+			// sorted to import the variables always in the same order
+			var keys []string
+			for key := range values {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				output.Context.Add(env, "{"+key+"}", toExampleString(values[key]))
+			}
+		}
+	}
+
+	content := strings.Replace(string(data), "\r\n", "\n", -1)
+	blocks := [][]string{{}}
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, httpFileSeparator) {
+			blocks = append(blocks, []string{line})
+		} else {
+			blocks[len(blocks)-1] = append(blocks[len(blocks)-1], line)
+		}
+	}
+
+	for _, block := range blocks {
+		mrd, found := parseHTTPFileBlock(block, &output.Context, &warnings)
+		if found {
+			addImportedRequest(&output, mrd, &warnings)
+		}
+	}
+
+	if len(output.Data) == 0 {
+		return output, warnings, errors.New("no request found in the .http file")
+	}
+	return output, warnings, nil
+}
+
+// parseHTTPFileBlock parses the lines between two "###" separators
+func parseHTTPFileBlock(lines []string, context *models.Context, warnings *[]string) (models.MakeRequestData, bool) {
+	mrd := models.EmptyMakeRequestData()
+	mrd.ContentType = ""
+	variables := make(map[string]string)
+	found := false
+
+	i := 0
+	// Separator, comments & variables
+	for ; i < len(lines) && !found; i++ {
+		line := strings.TrimSpace(lines[i])
+		switch {
+		case strings.HasPrefix(line, httpFileSeparator):
+			mrd.Alias = strings.TrimSpace(strings.TrimPrefix(line, httpFileSeparator))
+		case strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//"):
+			comment := strings.TrimSpace(strings.TrimLeft(line, "#/"))
+			if strings.HasPrefix(comment, httpFileProjectTag+" ") {
+				mrd.ProjectName = strings.TrimSpace(strings.TrimPrefix(comment, httpFileProjectTag))
+			} else if strings.HasPrefix(comment, httpFileNameTag+" ") && mrd.Alias == "" {
+				mrd.Alias = strings.TrimSpace(strings.TrimPrefix(comment, httpFileNameTag))
+			}
+		case line == "":
+		case httpFileVariableRegexp.MatchString(line):
+			match := httpFileVariableRegexp.FindStringSubmatch(line)
+			variables[match[1]] = match[2]
+		default:
+			if match := httpFileRequestLineRegexp.FindStringSubmatch(line); match != nil {
+				mrd.Method = types.Method(strings.ToUpper(match[1]))
+				mrd.URL = types.URL(match[2])
+			} else {
+				mrd.Method = httpFileDefaultMethod
+				mrd.URL = types.URL(strings.Fields(line)[0])
+			}
+			found = true
+		}
+	}
+
+	// Variables on top of the file (before the first separator) are file variables
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], httpFileSeparator) {
+		for key, value := range variables {
+			context.Add("default", "{"+key+"}", convertPostmanVariables(value, warnings))
+		}
+		variables = make(map[string]string)
+	}
+	if !found {
+		return mrd, false
+	}
+
+	// Query continuation lines & headers
+	var disabledParams []models.QueryParam
+	for ; i < len(lines) && strings.TrimSpace(lines[i]) != ""; i++ {
+		line := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(line, "?") || strings.HasPrefix(line, "&") {
+			mrd.URL = types.URL(mrd.URL.String() + line)
+			continue
+		}
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			// the disabled query params & headers are exported as comments
+			if match := httpFileDisabledQueryParamRegexp.FindStringSubmatch(line); match != nil {
+				for _, param := range models.ParseQueryParams(types.URL("?" + convertPostmanVariables(match[1], warnings))) {
+					param.Enabled = false
+					disabledParams = append(disabledParams, param)
+				}
+			} else if match := httpFileDisabledHeaderRegexp.FindStringSubmatch(line); match != nil && !strings.EqualFold(match[1], "Content-Type") {
+				mrd = mrd.SetHeader(match[1], convertPostmanVariables(match[2], warnings), false)
+			}
+			continue
+		}
+		index := strings.Index(line, ":")
+		if index == -1 {
+			*warnings = append(*warnings, "'"+mrd.Alias+"': invalid header '"+line+"'")
+			continue
+		}
+		key := strings.TrimSpace(line[:index])
+		value := convertPostmanVariables(strings.TrimSpace(line[index+1:]), warnings)
+		if strings.EqualFold(key, "Content-Type") {
+			mrd.ContentType = value
+		} else if credentials := strings.TrimPrefix(value, "Basic "); strings.EqualFold(key, "Authorization") && credentials != value && strings.Contains(credentials, ":") {
+			// "user:password" (not encoded) is exported from the Basic authentication helper
+			index := strings.Index(credentials, ":")
+			mrd.Auth = models.Auth{Type: models.AuthBasic, Username: credentials[:index], Password: credentials[index+1:]}
+		} else {
+			mrd = mrd.SetHeader(key, value, true)
+		}
+	}
+	if len(disabledParams) > 0 {
+		mrd.QueryParams = append(models.ParseQueryParams(mrd.URL), disabledParams...)
+	}
+
+	// Body (without the response handlers)
+	var body []string
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "> {%") || strings.HasPrefix(trimmed, ">{%"):
+			*warnings = append(*warnings, "'"+mrd.Alias+"': response handler script not imported")
+			for ; i < len(lines) && !strings.Contains(lines[i], "%}"); i++ {
+			}
+			continue
+		case strings.HasPrefix(trimmed, "<> ") || strings.HasPrefix(trimmed, ">> "):
+			continue
+		case strings.HasPrefix(trimmed, "< "):
+			*warnings = append(*warnings, "'"+mrd.Alias+"': body file '"+strings.TrimSpace(trimmed[1:])+"' not imported")
+			continue
+		}
+		body = append(body, line)
+	}
+	mrd.Body = convertPostmanVariables(strings.Trim(strings.Join(body, "\n"), "\n"), warnings)
+
+	mrd.URL = types.URL(convertPostmanVariables(mrd.URL.String(), warnings))
+	for key, value := range variables {
+		mrd.PathParams["{"+strings.ToLower(key)+"}"] = convertPostmanVariables(value, warnings)
+	}
+
+	if mrd.ContentType == "" {
+		mrd.ContentType = "application/json"
+	}
+	return mrd, true
+}
@@ -0,0 +1,198 @@
+package converters
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/joakim-ribier/gttp/core"
+	"github.com/joakim-ribier/gttp/models"
+)
+
+// Test 'ExportHTTPFile' & 'ImportHTTPFile' methods
+func TestHTTPFileRoundTrip(t *testing.T) {
+	var output models.Output
+	output.AddOrReplace(models.NewMakeRequestData("GET", "http://{hostname}/users/{id}", core.StringMap{"{id}": "124", "X-Token": "{token}"}, "", "application/json", "users", "Get user"))
+	output.AddOrReplace(models.NewMakeRequestData("POST", "http://{hostname}/users", core.StringMap{}, "{\"name\":\"bob\"}", "application/json", "users", "Create user"))
+	output.AddOrReplace(models.NewMakeRequestData("DELETE", "http://{hostname}/jira", core.StringMap{}, "", "application/json", "jira", "Delete"))
+	output.Context.Add("dev", "{hostname}", "server.dev")
+	output.Context.Add("dev", "{token}", "secret")
+
+	content, env, _ := ExportHTTPFile(output, "users")
+
+	if !strings.Contains(content, "GET http://{{hostname}}/users/124") || !strings.Contains(content, "X-Token: {{token}}") {
+		t.Error("Expected '{{var}}' placeholders & inline path param, got ", content)
+	}
+	if strings.Contains(content, "@id") {
+		t.Error("Expected no file variable, got ", content)
+	}
+	if strings.Contains(content, "jira") {
+		t.Error("Expected only 'users' project, got ", content)
+	}
+
+	imported, warnings, error := ImportHTTPFile([]byte(content), []byte(env))
+
+	if error != nil || len(warnings) != 0 {
+		t.Fatal("Expected nil, got ", error, warnings)
+	}
+	if len(imported.Data) != 2 {
+		t.Fatal("Expected len(2), got ", len(imported.Data))
+	}
+	for _, expected := range output.Data[1:] {
+		if expected.Method == "GET" {
+			expected = models.NewMakeRequestData("GET", "http://{hostname}/users/124", core.StringMap{"X-Token": "{token}"}, "", "application/json", "users", "Get user")
+		}
+		actual, error := imported.Find(expected.Method.String(), expected.URL.String())
+		if error != nil || !reflect.DeepEqual(expected.Normalized(), actual.Normalized()) {
+			t.Error("Expected ", expected, ", got ", actual, error)
+		}
+	}
+	if !reflect.DeepEqual(output.Context, imported.Context) {
+		t.Error("Expected ", output.Context, ", got ", imported.Context)
+	}
+}
+
+func TestImportHTTPFile(t *testing.T) {
+	data := `@host = localhost
+
+# @name list
+GET http://{{host}}/items
+    ?page=1
+    &size=10
+Accept: */*
+
+###
+POST http://{{host}}/items HTTP/1.1
+Content-Type: text/plain
+
+hello
+
+> {% client.global.set("id", response.body.id); %}
+`
+
+	output, warnings, error := ImportHTTPFile([]byte(data), nil)
+
+	if error != nil || len(output.Data) != 2 || len(warnings) != 1 {
+		t.Fatal("Expected 2 requests & 1 warning, got ", error, output.Data, warnings)
+	}
+	get, _ := output.Find("GET", "http://{host}/items?page=1&size=10")
+	if get.Alias != "list" || get.MapRequestHeaderKeyValue["Accept"] != "*/*" {
+		t.Error("Expected 'list' request, got ", get)
+	}
+	post, _ := output.Find("POST", "http://{host}/items")
+	if post.Body != "hello" || post.ContentType != "text/plain" {
+		t.Error("Expected 'hello' body, got ", post)
+	}
+	if output.Context.GetAllKeyValue("default")["{host}"] != "localhost" {
+		t.Error("Expected '{host}' variable, got ", output.Context.Env)
+	}
+}
+
+// Test 'ExportHTTPFile' & 'ImportHTTPFile' methods with a disabled header (exported as comment)
+func TestHTTPFileDisabledHeader(t *testing.T) {
+	var output models.Output
+	mrd := models.NewMakeRequestData("GET", "http://localhost/users", core.StringMap{"If-None-Match": "abc", "X-Token": "{token}"}, "", "", "", "List users")
+	output.AddOrReplace(mrd.SetHeaderEnabled("If-None-Match", false))
+
+	content, _, _ := ExportHTTPFile(output, "")
+
+	if !strings.Contains(content, "# If-None-Match: abc\n") || !strings.Contains(content, "\nX-Token: {token}\n") {
+		t.Error("Expected the disabled header as comment, got ", content)
+	}
+
+	imported, _, error := ImportHTTPFile([]byte(content), nil)
+	if error != nil {
+		t.Fatal("Expected nil, got ", error)
+	}
+	get, _ := imported.Find("GET", "http://localhost/users")
+	if get.IsHeaderEnabled("If-None-Match") || get.GetHeaders()["If-None-Match"] != "abc" {
+		t.Error("Expected 'If-None-Match' disabled, got ", get.GetHeaders(), get.MapDisabledHeaderKeyValue)
+	}
+	if expected := (core.StringMap{"X-Token": "{token}"}); !reflect.DeepEqual(get.GetHTTPHeaderValues(), expected) {
+		t.Error("Expected ", expected, ", got ", get.GetHTTPHeaderValues())
+	}
+}
+
+// Test 'ImportHTTPFile' method with the same header disabled & enabled (the enabled one is kept)
+func TestImportHTTPFileHeaderDisabledAndEnabled(t *testing.T) {
+	data := "### me\nGET http://localhost/me\n# Authorization: x\nAuthorization: y\n"
+
+	output, _, error := ImportHTTPFile([]byte(data), nil)
+	if error != nil {
+		t.Fatal("Expected nil, got ", error)
+	}
+	get, _ := output.Find("GET", "http://localhost/me")
+	if !get.IsHeaderEnabled("Authorization") || len(get.MapDisabledHeaderKeyValue) != 0 || get.GetHTTPHeaderValues()["Authorization"] != "y" {
+		t.Error("Expected 'Authorization: y' enabled only, got ", get.MapRequestHeaderKeyValue, get.MapDisabledHeaderKeyValue)
+	}
+}
+
+// Test 'ExportHTTPFile' & 'ImportHTTPFile' methods with the authentication & the disabled query params
+func TestHTTPFileAuthAndQueryParams(t *testing.T) {
+	var output models.Output
+	bearer := models.SimpleMakeRequestData("GET", "http://localhost/users?page=1", "users", "List users").
+		SetQueryParams([]models.QueryParam{{Key: "page", Value: "1", Enabled: true}, {Key: "sort", Value: "name", Enabled: false}})
+	bearer.Auth = models.Auth{Type: models.AuthBearer, Token: "{token}"}
+	bearer.Timeout = models.Timeout{Total: "5s"}
+	bearer.Assertions = []models.Assertion{models.NewAssertion(models.AssertStatusEquals, "", "200")}
+	basic := models.SimpleMakeRequestData("GET", "http://localhost/me", "users", "Me")
+	basic.Auth = models.Auth{Type: models.AuthBasic, Username: "bob", Password: "{password}"}
+	apiKey := models.SimpleMakeRequestData("GET", "http://localhost/keys", "users", "Keys")
+	apiKey.Auth = models.Auth{Type: models.AuthAPIKeyQuery, Key: "api_key", Token: "{api_key}"}
+	output.Data = []models.MakeRequestData{bearer, basic, apiKey}
+	output.Context.Add("dev", "{token}", "abc")
+	output.Context.Add("dev", "{password}", "secret")
+	output.Context.Add("dev", "{api_key}", "key")
+
+	content, env, warnings := ExportHTTPFile(output, "")
+
+	for _, expected := range []string{"Authorization: Bearer {{token}}\n", "# ?sort=name\n", "Authorization: Basic bob:{{password}}\n", "GET http://localhost/keys?api_key={{api_key}}\n"} {
+		if !strings.Contains(content, expected) {
+			t.Error("Expected ", expected, ", got ", content)
+		}
+	}
+	if len(warnings) != 2 {
+		t.Error("Expected 2 warnings (timeout, assertions), got ", warnings)
+	}
+
+	imported, _, error := ImportHTTPFile([]byte(content), []byte(env))
+	if error != nil {
+		t.Fatal("Expected nil, got ", error)
+	}
+	list, _ := imported.FindByAlias("users", "List users")
+	if list.GetHTTPHeaderValues()["Authorization"] != "Bearer {token}" {
+		t.Error("Expected the bearer token header, got ", list.GetHTTPHeaderValues())
+	}
+	if expected := []models.QueryParam{{Key: "page", Value: "1", Enabled: true}, {Key: "sort", Value: "name", Enabled: false}}; !reflect.DeepEqual(list.QueryParams, expected) {
+		t.Error("Expected ", expected, ", got ", list.QueryParams)
+	}
+	if me, _ := imported.FindByAlias("users", "Me"); me.Auth != basic.Auth {
+		t.Error("Expected ", basic.Auth, ", got ", me.Auth)
+	}
+}
+
+// Test 'MergeHTTPFileEnv' method (the existing environments & variables are kept)
+func TestMergeHTTPFileEnv(t *testing.T) {
+	existing := `{"dev": {"hostname": "old.dev", "user": "bob"}, "local": {"hostname": "localhost"}}`
+
+	merged, error := MergeHTTPFileEnv([]byte(existing), []byte(`{"dev": {"hostname": "server.dev"}, "prod": {"hostname": "server.io"}}`))
+	if error != nil {
+		t.Fatal("Expected nil, got ", error)
+	}
+
+	var envs map[string]map[string]string
+	json.Unmarshal(merged, &envs)
+	expected := map[string]map[string]string{
+		"dev":   {"hostname": "server.dev", "user": "bob"},
+		"local": {"hostname": "localhost"},
+		"prod":  {"hostname": "server.io"},
+	}
+	if !reflect.DeepEqual(envs, expected) {
+		t.Error("Expected ", expected, ", got ", envs)
+	}
+
+	if _, error := MergeHTTPFileEnv([]byte("{invalid"), []byte(`{}`)); error == nil {
+		t.Error("Expected error, got nil")
+	}
+}
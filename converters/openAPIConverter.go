@@ -0,0 +1,388 @@
+package converters
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/joakim-ribier/gttp/core"
+	"github.com/joakim-ribier/gttp/models"
+	"gopkg.in/yaml.v3"
+)
+
+// openAPIBaseURLVariable returns the context variable which contains the server url of the spec,
+// it's scoped to the project to not replace the server url of another imported spec ("Pet Store" => "{pet-store-baseurl}")
+func openAPIBaseURLVariable(projectName string) string {
+	prefix := strings.Trim(openAPIEnvRegexp.ReplaceAllString(strings.ToLower(projectName), "-"), "-")
+	if prefix == "" {
+		return "{baseurl}"
+	}
+	return "{" + prefix + "-baseurl}"
+}
+
+// openAPIMethods lists the operations of a path item (in the display order)
+var openAPIMethods = []string{"get", "post", "put", "patch", "delete", "head", "options", "trace"}
+
+// openAPIEnvRegexp matches the characters not allowed in an environment name
+var openAPIEnvRegexp = regexp.MustCompile(`[^a-z0-9_\-]+`)
+
+// openAPISpec wraps the decoded spec (json or yaml) to resolve the "$ref" values
+type openAPISpec struct {
+	root     map[string]interface{}
+	warnings []string
+}
+
+// ImportOpenAPI converts an OpenAPI 3 or a Swagger 2 spec (json or yaml) to gttp requests,
+// the servers of the spec are imported as execution contexts (variable "{<project>-baseurl}").
+func ImportOpenAPI(data []byte) (models.Output, []string, error) {
+	var output models.Output
+	var root map[string]interface{}
+
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return output, nil, errors.New("invalid OpenAPI spec: " + err.Error())
+	}
+
+	spec := &openAPISpec{root: root}
+	version := spec.getString(root, "openapi")
+	swagger := spec.getString(root, "swagger")
+	if !strings.HasPrefix(version, "3.") && !strings.HasPrefix(swagger, "2.") {
+		return output, nil, errors.New("invalid OpenAPI spec: 'openapi: 3.x' or 'swagger: 2.0' expected")
+	}
+
+	projectName := spec.getString(spec.getMap(root, "info"), "title")
+	baseURLVariable := openAPIBaseURLVariable(projectName)
+
+	// Servers => execution contexts
+	servers := spec.servers(swagger != "")
+	for index, server := range servers {
+		if index == 0 {
+			output.Context.Add("default", baseURLVariable, server[1])
+		}
+		output.Context.Add(server[0], baseURLVariable, server[1])
+	}
+	if len(servers) == 0 {
+		spec.warn("no server defined, set the '" + baseURLVariable + "' variable in the settings")
+	}
+
+	if _, exists := root["security"]; exists {
+		spec.warn("security requirements not imported")
+	}
+
+	// Paths => requests
+	paths := spec.getMap(root, "paths")
+	for _, path := range sortedMapKeys(paths) {
+		pathItem := spec.resolve(paths[path])
+		pathParameters := spec.getSlice(pathItem, "parameters")
+
+		for _, method := range openAPIMethods {
+			operation, exists := pathItem[method]
+			if !exists {
+				continue
+			}
+			mrd := spec.convertOperation(projectName, strings.ToUpper(method), path, pathParameters, spec.resolve(operation), swagger != "")
+			addImportedRequest(&output, mrd, &spec.warnings)
+		}
+	}
+
+	return output, spec.warnings, nil
+}
+
+// servers returns the [env, url] list of the spec servers
+func (spec *openAPISpec) servers(isSwagger bool) [][2]string {
+	var servers [][2]string
+	if isSwagger {
+		host := spec.getString(spec.root, "host")
+		if host == "" {
+			return servers
+		}
+		schemes := spec.getSlice(spec.root, "schemes")
+		if len(schemes) == 0 {
+			schemes = []interface{}{"https"}
+		}
+		for _, scheme := range schemes {
+			value, _ := scheme.(string)
+			servers = append(servers, [2]string{value, value + "://" + host + spec.getString(spec.root, "basePath")})
+		}
+		return servers
+	}
+
+	for index, value := range spec.getSlice(spec.root, "servers") {
+		server := spec.resolve(value)
+		url := spec.getString(server, "url")
+		for name, variable := range spec.getMap(server, "variables") {
+			url = strings.Replace(url, "{"+name+"}", spec.getString(spec.resolve(variable), "default"), -1)
+		}
+		env := openAPIEnvRegexp.ReplaceAllString(strings.ToLower(spec.getString(server, "description")), "-")
+		env = strings.Trim(env, "-")
+		if env == "" || env == "default" {
+			env = "server-" + strconv.Itoa(index+1)
+		}
+		servers = append(servers, [2]string{env, strings.TrimSuffix(url, "/")})
+	}
+	return servers
+}
+
+func (spec *openAPISpec) convertOperation(projectName string, method string, path string, pathParameters []interface{}, operation map[string]interface{}, isSwagger bool) models.MakeRequestData {
+	alias := spec.getString(operation, "operationId")
+	if alias == "" {
+		alias = spec.getString(operation, "summary")
+	}
+	if alias == "" {
+		alias = method + " " + path
+	}
+
+	mrd := models.NewMakeRequestData(method, openAPIBaseURLVariable(projectName)+path, make(core.StringMap), "", "application/json", projectName, alias)
+
+	var query []models.QueryParam
+	var formData []string
+	parameters := append(append([]interface{}{}, pathParameters...), spec.getSlice(operation, "parameters")...)
+	for _, value := range parameters {
+		parameter := spec.resolve(value)
+		name := spec.getString(parameter, "name")
+		example := spec.parameterExample(parameter)
+
+		switch spec.getString(parameter, "in") {
+		case "path":
+			mrd.PathParams["{"+name+"}"] = example
+		case "query":
+			// the optional params are imported disabled
+			required, _ := parameter["required"].(bool)
+			query = append(query, models.QueryParam{Key: name, Value: example, Enabled: required})
+		case "header":
+			mrd = mrd.SetHeader(name, example, true)
+		case "body":
+			mrd.Body = spec.example(spec.resolve(parameter["schema"]), 0)
+		case "formData":
+			formData = append(formData, name+"="+example)
+		case "cookie":
+			spec.warn("'" + alias + "': cookie parameter '" + name + "' not imported")
+		}
+	}
+	mrd = mrd.SetQueryParams(query)
+
+	if isSwagger {
+		if consumes := spec.getSlice(operation, "consumes"); len(consumes) > 0 {
+			mrd.ContentType, _ = consumes[0].(string)
+		} else if consumes := spec.getSlice(spec.root, "consumes"); len(consumes) > 0 {
+			mrd.ContentType, _ = consumes[0].(string)
+		}
+		if len(formData) > 0 {
+			mrd.Body = strings.Join(formData, "&")
+			mrd.ContentType = "application/x-www-form-urlencoded"
+		}
+	} else if requestBody := spec.resolve(operation["requestBody"]); requestBody != nil {
+		content := spec.getMap(requestBody, "content")
+		contentTypes := sortedMapKeys(content)
+		for index, contentType := range contentTypes {
+			if strings.Contains(contentType, "json") {
+				contentTypes[0], contentTypes[index] = contentTypes[index], contentTypes[0]
+				break
+			}
+		}
+		if len(contentTypes) > 0 {
+			mrd.ContentType = contentTypes[0]
+			mrd.Body = spec.mediaTypeExample(spec.resolve(content[contentTypes[0]]))
+		}
+	}
+
+	if _, exists := operation["security"]; exists {
+		spec.warn("'" + alias + "': security requirements not imported")
+	}
+
+	return mrd
+}
+
+// parameterExample returns the example (or the default value) of the parameter
+func (spec *openAPISpec) parameterExample(parameter map[string]interface{}) string {
+	if value, exists := parameter["example"]; exists {
+		return toExampleString(value)
+	}
+	for _, value := range spec.getMap(parameter, "examples") {
+		if example, exists := spec.resolve(value)["value"]; exists {
+			return toExampleString(example)
+		}
+	}
+	schema := spec.resolve(parameter["schema"])
+	if schema == nil {
+		// swagger 2 parameters define the type in the parameter itself
+		schema = parameter
+	}
+	for _, key := range []string{"example", "default"} {
+		if value, exists := schema[key]; exists {
+			return toExampleString(value)
+		}
+	}
+	return ""
+}
+
+// mediaTypeExample returns the example body of the media type (example, examples or built from the schema)
+func (spec *openAPISpec) mediaTypeExample(mediaType map[string]interface{}) string {
+	if value, exists := mediaType["example"]; exists {
+		return toExampleString(value)
+	}
+	examples := spec.getMap(mediaType, "examples")
+	for _, key := range sortedMapKeys(examples) {
+		if example, exists := spec.resolve(examples[key])["value"]; exists {
+			return toExampleString(example)
+		}
+	}
+	return spec.example(spec.resolve(mediaType["schema"]), 0)
+}
+
+// example builds an example value from the @schema
+func (spec *openAPISpec) example(schema map[string]interface{}, depth int) string {
+	if schema == nil {
+		return ""
+	}
+	value := spec.exampleValue(schema, depth)
+	if value == nil {
+		return ""
+	}
+	return toExampleString(value)
+}
+
+func (spec *openAPISpec) exampleValue(schema map[string]interface{}, depth int) interface{} {
+	if schema == nil || depth > 8 {
+		return nil
+	}
+	for _, key := range []string{"example", "default"} {
+		if value, exists := schema[key]; exists {
+			return value
+		}
+	}
+	if enum := spec.getSlice(schema, "enum"); len(enum) > 0 {
+		return enum[0]
+	}
+	for _, key := range []string{"allOf", "oneOf", "anyOf"} {
+		if schemas := spec.getSlice(schema, key); len(schemas) > 0 {
+			if key != "allOf" {
+				return spec.exampleValue(spec.resolve(schemas[0]), depth+1)
+			}
+			merged := make(map[string]interface{})
+			for _, value := range schemas {
+				if object, ok := spec.exampleValue(spec.resolve(value), depth+1).(map[string]interface{}); ok {
+					for k, v := range object {
+						merged[k] = v
+					}
+				}
+			}
+			return merged
+		}
+	}
+
+	switch spec.getString(schema, "type") {
+	case "string":
+		switch spec.getString(schema, "format") {
+		case "date":
+			return "2006-01-02"
+		case "date-time":
+			return "2006-01-02T15:04:05Z"
+		}
+		return "string"
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	case "array":
+		if item := spec.exampleValue(spec.resolve(schema["items"]), depth+1); item != nil {
+			return []interface{}{item}
+		}
+		return []interface{}{}
+	default:
+		properties := spec.getMap(schema, "properties")
+		if len(properties) == 0 && spec.getString(schema, "type") != "object" {
+			return nil
+		}
+		object := make(map[string]interface{})
+		for name, property := range properties {
+			object[name] = spec.exampleValue(spec.resolve(property), depth+1)
+		}
+		return object
+	}
+}
+
+// resolve returns the value as map and follows the local "$ref" ("#/components/schemas/Pet")
+func (spec *openAPISpec) resolve(value interface{}) map[string]interface{} {
+	for i := 0; i < 16; i++ {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		ref, ok := object["$ref"].(string)
+		if !ok {
+			return object
+		}
+		if !strings.HasPrefix(ref, "#/") {
+			spec.warn("external reference '" + ref + "' not supported")
+			return nil
+		}
+		value = spec.root
+		for _, key := range strings.Split(ref[2:], "/") {
+			key = strings.Replace(strings.Replace(key, "~1", "/", -1), "~0", "~", -1)
+			if current, ok := value.(map[string]interface{}); ok {
+				value = current[key]
+			} else {
+				value = nil
+			}
+		}
+	}
+	return nil
+}
+
+func (spec *openAPISpec) warn(warning string) {
+	for _, value := range spec.warnings {
+		if value == warning {
+			return
+		}
+	}
+	spec.warnings = append(spec.warnings, warning)
+}
+
+func (spec *openAPISpec) getMap(object map[string]interface{}, key string) map[string]interface{} {
+	value, _ := object[key].(map[string]interface{})
+	return value
+}
+
+func (spec *openAPISpec) getSlice(object map[string]interface{}, key string) []interface{} {
+	value, _ := object[key].([]interface{})
+	return value
+}
+
+func (spec *openAPISpec) getString(object map[string]interface{}, key string) string {
+	switch value := object[key].(type) {
+	case string:
+		return value
+	case nil:
+		return ""
+	default:
+		return toExampleString(value)
+	}
+}
+
+// toExampleString converts an example value to string (json for objects & arrays)
+func toExampleString(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case nil:
+		return ""
+	case map[string]interface{}, []interface{}:
+		data, _ := json.MarshalIndent(value, "", "  ")
+		return string(data)
+	default:
+		data, _ := json.Marshal(value)
+		return string(data)
+	}
+}
+
+func sortedMapKeys(values map[string]interface{}) []string {
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
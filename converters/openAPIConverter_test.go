@@ -0,0 +1,173 @@
+package converters
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/joakim-ribier/gttp/models"
+)
+
+const openAPIYAML = `
+openapi: 3.0.1
+info:
+  title: Petstore
+servers:
+  - url: https://{region}.petstore.io/v1
+    description: Production
+    variables:
+      region:
+        default: eu
+  - url: http://localhost:8080/v1/
+paths:
+  /pets/{petId}:
+    parameters:
+      - $ref: '#/components/parameters/PetId'
+    get:
+      operationId: getPet
+      parameters:
+        - name: X-Request-Id
+          in: header
+          example: abc
+        - name: fields
+          in: query
+          example: name
+    put:
+      summary: Update a pet
+      parameters:
+        - name: dryRun
+          in: query
+          required: true
+          schema:
+            type: boolean
+            default: true
+      requestBody:
+        content:
+          application/xml: {}
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+components:
+  parameters:
+    PetId:
+      name: petId
+      in: path
+      required: true
+      schema:
+        type: integer
+        example: 42
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          example: rex
+        tags:
+          type: array
+          items:
+            type: string
+`
+
+// Test 'ImportOpenAPI' method
+func TestImportOpenAPI(t *testing.T) {
+	output, _, error := ImportOpenAPI([]byte(openAPIYAML))
+
+	if error != nil {
+		t.Fatal("Expected nil, got ", error)
+	}
+	if len(output.Data) != 2 {
+		t.Fatal("Expected len(2), got ", len(output.Data))
+	}
+
+	get, error := output.Find("GET", "{petstore-baseurl}/pets/{petId}")
+	if error != nil || get.ProjectName != "Petstore" || get.Alias != "getPet" {
+		t.Error("Expected 'Petstore' / 'getPet', got ", get.ProjectName, get.Alias, error)
+	}
+	if get.PathParams["{petId}"] != "42" || get.MapRequestHeaderKeyValue["X-Request-Id"] != "abc" {
+		t.Error("Expected path param & header, got ", get.PathParams, get.MapRequestHeaderKeyValue)
+	}
+	if expected := []models.QueryParam{{Key: "fields", Value: "name", Enabled: false}}; !reflect.DeepEqual(get.QueryParams, expected) {
+		t.Error("Expected ", expected, ", got ", get.QueryParams)
+	}
+
+	put, error := output.Find("PUT", "{petstore-baseurl}/pets/{petId}?dryRun=true")
+	if error != nil || put.Alias != "Update a pet" || put.ContentType != "application/json" {
+		t.Error("Expected 'Update a pet' json request, got ", put.Alias, put.ContentType, error)
+	}
+	if _, exists := put.PathParams["{dryRun}"]; exists || len(put.QueryParams) != 1 || !put.QueryParams[0].Enabled {
+		t.Error("Expected enabled 'dryRun' query param, got ", put.PathParams, put.QueryParams)
+	}
+	if !strings.Contains(put.Body, `"name": "rex"`) || !strings.Contains(put.Body, `"tags": [`) {
+		t.Error("Expected example body, got ", put.Body)
+	}
+
+	if actual := output.Context.GetAllKeyValue("production")["{petstore-baseurl}"]; actual != "https://eu.petstore.io/v1" {
+		t.Error("Expected 'https://eu.petstore.io/v1', got ", actual)
+	}
+	if actual := output.Context.GetAllKeyValue("server-2")["{petstore-baseurl}"]; actual != "http://localhost:8080/v1" {
+		t.Error("Expected 'http://localhost:8080/v1', got ", actual)
+	}
+	if actual := output.Context.GetAllKeyValue("default")["{petstore-baseurl}"]; actual != "https://eu.petstore.io/v1" {
+		t.Error("Expected 'https://eu.petstore.io/v1', got ", actual)
+	}
+}
+
+func TestImportSwagger(t *testing.T) {
+	data := `{"swagger": "2.0", "info": {"title": "Users"}, "host": "api.io", "basePath": "/v2", "schemes": ["https"],
+	  "paths": {"/users": {"post": {"operationId": "createUser", "consumes": ["application/json"],
+	    "parameters": [{"name": "body", "in": "body", "schema": {"type": "object", "example": {"name": "bob"}}}]}}}}`
+
+	output, _, error := ImportOpenAPI([]byte(data))
+
+	if error != nil || len(output.Data) != 1 {
+		t.Fatal("Expected 1 request, got ", error, output.Data)
+	}
+	if output.Data[0].Alias != "createUser" || !strings.Contains(output.Data[0].Body, `"name": "bob"`) {
+		t.Error("Expected 'createUser' with body, got ", output.Data[0])
+	}
+	if actual := output.Context.GetAllKeyValue("https")["{users-baseurl}"]; actual != "https://api.io/v2" {
+		t.Error("Expected 'https://api.io/v2', got ", actual)
+	}
+}
+
+// Test 'ImportOpenAPI' method with 2 specs (each project keeps its server url)
+func TestImportOpenAPIBaseURLByProject(t *testing.T) {
+	var output models.Output
+	for _, spec := range []string{
+		`{"openapi": "3.0.0", "info": {"title": "Pet Store"}, "servers": [{"url": "https://pets.io"}], "paths": {"/pets": {"get": {}}}}`,
+		`{"openapi": "3.0.0", "info": {"title": "Users"}, "servers": [{"url": "https://users.io"}], "paths": {"/users": {"get": {}}}}`,
+	} {
+		imported, _, error := ImportOpenAPI([]byte(spec))
+		if error != nil {
+			t.Fatal("Expected nil, got ", error)
+		}
+		output.Merge(imported)
+	}
+
+	values := output.Context.GetAllKeyValue("default")
+	if values["{pet-store-baseurl}"] != "https://pets.io" || values["{users-baseurl}"] != "https://users.io" {
+		t.Error("Expected a server url by project, got ", values)
+	}
+	if _, error := output.Find("GET", "{pet-store-baseurl}/pets"); error != nil {
+		t.Error("Expected '{pet-store-baseurl}/pets', got ", error)
+	}
+}
+
+// Test 'ImportOpenAPI' method with an operation without operationId and summary
+func TestImportOpenAPIAliasFallback(t *testing.T) {
+	output, _, error := ImportOpenAPI([]byte(`{"openapi": "3.0.0", "info": {"title": "Shop"}, "paths": {"/orders/{id}": {"delete": {}}}}`))
+	if error != nil {
+		t.Fatal("Expected nil, got ", error)
+	}
+
+	if actual := output.Data[0].Alias; actual != "DELETE /orders/{id}" {
+		t.Error("Expected 'DELETE /orders/{id}', got ", actual)
+	}
+}
+
+func TestImportOpenAPIInvalid(t *testing.T) {
+	if _, _, error := ImportOpenAPI([]byte(`{"info": {}}`)); error == nil {
+		t.Error("Expected error, got nil")
+	}
+}
@@ -0,0 +1,337 @@
+package converters
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/joakim-ribier/gttp/models"
+	"github.com/joakim-ribier/gttp/models/types"
+)
+
+// postmanVariableRegexp matches the Postman "{{variable}}" placeholders
+var postmanVariableRegexp = regexp.MustCompile(`\{\{\s*([^{}]+?)\s*\}\}`)
+
+// postmanPathVariableRegexp matches the Postman ":variable" path placeholders
+var postmanPathVariableRegexp = regexp.MustCompile(`/:([A-Za-z0-9_\-]+)`)
+
+type postmanCollection struct {
+	Info struct {
+		Name   string `json:"name"`
+		Schema string `json:"schema"`
+	} `json:"info"`
+	Item     []postmanItem     `json:"item"`
+	Variable []postmanKeyValue `json:"variable"`
+	Auth     *postmanAuth      `json:"auth"`
+	Event    []json.RawMessage `json:"event"`
+}
+
+type postmanItem struct {
+	Name    string            `json:"name"`
+	Item    []postmanItem     `json:"item"`
+	Request *postmanRequest   `json:"request"`
+	Auth    *postmanAuth      `json:"auth"`
+	Event   []json.RawMessage `json:"event"`
+}
+
+type postmanRequest struct {
+	Method string            `json:"method"`
+	Header []postmanKeyValue `json:"header"`
+	URL    json.RawMessage   `json:"url"`
+	Body   *postmanBody      `json:"body"`
+	Auth   *postmanAuth      `json:"auth"`
+}
+
+type postmanURL struct {
+	Raw      string            `json:"raw"`
+	Variable []postmanKeyValue `json:"variable"`
+}
+
+type postmanBody struct {
+	Mode       string            `json:"mode"`
+	Raw        string            `json:"raw"`
+	URLEncoded []postmanKeyValue `json:"urlencoded"`
+	FormData   []postmanKeyValue `json:"formdata"`
+	GraphQL    *struct {
+		Query     string `json:"query"`
+		Variables string `json:"variables"`
+	} `json:"graphql"`
+	Options struct {
+		Raw struct {
+			Language string `json:"language"`
+		} `json:"raw"`
+	} `json:"options"`
+}
+
+type postmanAuth struct {
+	Type   string            `json:"type"`
+	Basic  []postmanKeyValue `json:"basic"`
+	Bearer []postmanKeyValue `json:"bearer"`
+	APIKey []postmanKeyValue `json:"apikey"`
+}
+
+type postmanKeyValue struct {
+	Key      string          `json:"key"`
+	Value    json.RawMessage `json:"value"`
+	Type     string          `json:"type"`
+	Src      json.RawMessage `json:"src"`
+	Disabled bool            `json:"disabled"`
+	Enabled  *bool           `json:"enabled"`
+}
+
+type postmanEnvironment struct {
+	Name   string            `json:"name"`
+	Values []postmanKeyValue `json:"values"`
+}
+
+// ImportPostmanCollection converts a Postman (v2.1) collection to gttp requests,
+// the collection variables are imported in the "default" environment of the context.
+// It returns also the list of the unsupported items (scripts, auth helpers...).
+func ImportPostmanCollection(data []byte) (models.Output, []string, error) {
+	var output models.Output
+	var collection postmanCollection
+	var warnings []string
+
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return output, nil, errors.New("invalid Postman collection: " + err.Error())
+	}
+	if collection.Info.Name == "" && len(collection.Item) == 0 {
+		return output, nil, errors.New("invalid Postman collection: no 'info' and no 'item'")
+	}
+	if collection.Info.Schema != "" && !strings.Contains(collection.Info.Schema, "v2.1") && !strings.Contains(collection.Info.Schema, "v2.0") {
+		warnings = append(warnings, "schema '"+collection.Info.Schema+"' not supported, v2.1 expected")
+	}
+
+	if len(collection.Event) > 0 {
+		warnings = append(warnings, "collection '"+collection.Info.Name+"': scripts not imported")
+	}
+
+	for _, variable := range collection.Variable {
+		output.Context.Add("default", "{"+variable.Key+"}", convertPostmanVariables(variable.value(), &warnings))
+	}
+
+	var walk func(items []postmanItem, projectName string, auth *postmanAuth)
+	walk = func(items []postmanItem, projectName string, auth *postmanAuth) {
+		for _, item := range items {
+			itemAuth := auth
+			if item.Auth != nil {
+				itemAuth = item.Auth
+			}
+			if len(item.Event) > 0 {
+				warnings = append(warnings, "'"+item.Name+"': scripts not imported")
+			}
+			if item.Request == nil {
+				// It's a folder
+				walk(item.Item, item.Name, itemAuth)
+				continue
+			}
+			if item.Request.Auth != nil {
+				itemAuth = item.Request.Auth
+			}
+			mrd := convertPostmanRequest(item, projectName, itemAuth, &warnings)
+			addImportedRequest(&output, mrd, &warnings)
+		}
+	}
+	walk(collection.Item, collection.Info.Name, collection.Auth)
+
+	return output, warnings, nil
+}
+
+// addImportedRequest adds the imported request at the end to keep the source order, its alias is renamed (with a warning)
+// if it's already used in the project to keep all the requests (ex. the same endpoint with different bodies)
+func addImportedRequest(output *models.Output, mrd models.MakeRequestData, warnings *[]string) {
+	if alias := output.UniqueAlias(mrd.ProjectName, mrd.Alias); alias != mrd.Alias {
+		*warnings = append(*warnings, "'"+mrd.Alias+"': duplicate name renamed '"+alias+"'")
+		mrd.Alias = alias
+	}
+	output.Data = append(output.Data, mrd)
+}
+
+// ImportPostmanEnvironment converts a Postman environment to a gttp context environment
+func ImportPostmanEnvironment(data []byte) (models.Context, []string, error) {
+	var context models.Context
+	var environment postmanEnvironment
+	var warnings []string
+
+	if err := json.Unmarshal(data, &environment); err != nil {
+		return context, nil, errors.New("invalid Postman environment: " + err.Error())
+	}
+	if environment.Name == "" {
+		return context, nil, errors.New("invalid Postman environment: no 'name'")
+	}
+
+	for _, value := range environment.Values {
+		if value.Enabled != nil && !*value.Enabled {
+			warnings = append(warnings, "variable '"+value.Key+"' disabled, not imported")
+			continue
+		}
+		context.Add(environment.Name, "{"+value.Key+"}", convertPostmanVariables(value.value(), &warnings))
+	}
+
+	return context, warnings, nil
+}
+
+func convertPostmanRequest(item postmanItem, projectName string, auth *postmanAuth, warnings *[]string) models.MakeRequestData {
+	request := item.Request
+	mrd := models.EmptyMakeRequestData()
+	mrd.ProjectName = projectName
+	mrd.Alias = item.Name
+	if request.Method != "" {
+		mrd.Method = types.Method(strings.ToUpper(request.Method))
+	}
+
+	// URL is a string or an object
+	var rawURL postmanURL
+	if err := json.Unmarshal(request.URL, &rawURL.Raw); err != nil {
+		json.Unmarshal(request.URL, &rawURL)
+	}
+	URL := postmanPathVariableRegexp.ReplaceAllStringFunc(rawURL.Raw, func(value string) string {
+		return "/{" + strings.ToLower(value[2:]) + "}"
+	})
+	mrd.URL = types.URL(convertPostmanVariables(URL, warnings))
+	for _, variable := range rawURL.Variable {
+		mrd.PathParams["{"+strings.ToLower(variable.Key)+"}"] = convertPostmanVariables(variable.value(), warnings)
+	}
+
+	for _, header := range request.Header {
+		value := convertPostmanVariables(header.value(), warnings)
+		if strings.EqualFold(header.Key, "Content-Type") {
+			if header.Disabled {
+				*warnings = append(*warnings, "'"+item.Name+"': disabled header '"+header.Key+"' not imported")
+				continue
+			}
+			mrd.ContentType = value
+		} else {
+			mrd = mrd.SetHeader(header.Key, value, !header.Disabled)
+		}
+	}
+
+	if request.Body != nil {
+		convertPostmanBody(&mrd, item.Name, request.Body, warnings)
+	}
+	if auth != nil {
+		convertPostmanAuth(&mrd, item.Name, auth, warnings)
+	}
+
+	return mrd
+}
+
+func convertPostmanBody(mrd *models.MakeRequestData, name string, body *postmanBody, warnings *[]string) {
+	switch body.Mode {
+	case "raw":
+		mrd.Body = convertPostmanVariables(body.Raw, warnings)
+		switch body.Options.Raw.Language {
+		case "xml":
+			mrd.ContentType = "application/xml"
+		case "text":
+			mrd.ContentType = "text/plain"
+		case "html":
+			mrd.ContentType = "text/html"
+		case "javascript":
+			mrd.ContentType = "application/javascript"
+		}
+	case "urlencoded":
+		var values []string
+		for _, value := range body.URLEncoded {
+			if !value.Disabled {
+				values = append(values, url.QueryEscape(value.Key)+"="+url.QueryEscape(convertPostmanVariables(value.value(), warnings)))
+			}
+		}
+		mrd.Body = strings.Join(values, "&")
+		mrd.ContentType = "application/x-www-form-urlencoded"
+	case "formdata":
+		var buf bytes.Buffer
+		writer := multipart.NewWriter(&buf)
+		writer.SetBoundary(multipartBoundary)
+		for _, value := range body.FormData {
+			if value.Disabled {
+				continue
+			}
+			if value.Type == "file" {
+				*warnings = append(*warnings, "'"+name+"': form file '"+value.Key+"' not imported")
+				continue
+			}
+			writer.WriteField(value.Key, convertPostmanVariables(value.value(), warnings))
+		}
+		writer.Close()
+		mrd.Body = buf.String()
+		mrd.ContentType = "multipart/form-data; boundary=" + multipartBoundary
+	case "graphql":
+		if body.GraphQL != nil {
+			graphQL := map[string]interface{}{"query": body.GraphQL.Query}
+			var variables interface{}
+			if json.Unmarshal([]byte(body.GraphQL.Variables), &variables) == nil {
+				graphQL["variables"] = variables
+			}
+			value, _ := json.Marshal(graphQL)
+			mrd.Body = convertPostmanVariables(string(value), warnings)
+			mrd.ContentType = "application/json"
+		}
+	case "":
+	default:
+		*warnings = append(*warnings, "'"+name+"': body mode '"+body.Mode+"' not supported")
+	}
+}
+
+func convertPostmanAuth(mrd *models.MakeRequestData, name string, auth *postmanAuth, warnings *[]string) {
+	get := func(values []postmanKeyValue, key string) string {
+		for _, value := range values {
+			if value.Key == key {
+				return convertPostmanVariables(value.value(), warnings)
+			}
+		}
+		return ""
+	}
+
+	switch auth.Type {
+	case "noauth", "":
+	case "bearer":
+		*mrd = mrd.SetHeader("Authorization", "Bearer "+get(auth.Bearer, "token"), true)
+	case "apikey":
+		if get(auth.APIKey, "in") == "query" {
+			*warnings = append(*warnings, "'"+name+"': API key in query string not supported")
+			return
+		}
+		*mrd = mrd.SetHeader(get(auth.APIKey, "key"), get(auth.APIKey, "value"), true)
+	case "basic":
+		username, password := get(auth.Basic, "username"), get(auth.Basic, "password")
+		if strings.Contains(username+password, "{") {
+			*warnings = append(*warnings, "'"+name+"': basic auth helper with variables not supported")
+			return
+		}
+		*mrd = mrd.SetHeader("Authorization", basicAuthorization(username, password), true)
+	default:
+		*warnings = append(*warnings, "'"+name+"': auth helper '"+auth.Type+"' not supported")
+	}
+}
+
+// convertPostmanVariables translates the Postman "{{var}}" to the gttp "{var}" placeholders
+func convertPostmanVariables(value string, warnings *[]string) string {
+	return postmanVariableRegexp.ReplaceAllStringFunc(value, func(match string) string {
+		name := postmanVariableRegexp.FindStringSubmatch(match)[1]
+		if strings.HasPrefix(name, "$") {
+			*warnings = append(*warnings, "dynamic variable '"+match+"' not supported")
+			return match
+		}
+		return "{" + strings.ToLower(name) + "}"
+	})
+}
+
+// value returns the value as string (Postman values could be string, number or boolean)
+func (kv postmanKeyValue) value() string {
+	var value string
+	if err := json.Unmarshal(kv.Value, &value); err == nil {
+		return value
+	}
+	return strings.Trim(string(kv.Value), "\"")
+}
+
+// basicAuthorization builds a "Basic" authorization header value
+func basicAuthorization(username string, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
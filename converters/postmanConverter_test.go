@@ -0,0 +1,147 @@
+package converters
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/joakim-ribier/gttp/models"
+)
+
+const postmanCollectionJSON = `{
+  "info": {"name": "Jira", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+  "variable": [{"key": "baseUrl", "value": "https://jira.io"}],
+  "item": [
+    {"name": "Tickets", "item": [
+      {"name": "Get ticket", "event": [{"listen": "test", "script": {"exec": ["pm.test()"]}}],
+       "request": {"method": "GET", "header": [{"key": "X-Token", "value": "{{token}}"}, {"key": "X-Off", "value": "1", "disabled": true}],
+         "url": {"raw": "{{baseUrl}}/ticket/:id", "variable": [{"key": "id", "value": "42"}]},
+         "auth": {"type": "bearer", "bearer": [{"key": "token", "value": "{{token}}", "type": "string"}]}}}
+    ]},
+    {"name": "Create ticket",
+     "request": {"method": "post", "url": "{{baseUrl}}/ticket",
+       "body": {"mode": "raw", "raw": "{\"name\": \"{{name}}\"}", "options": {"raw": {"language": "json"}}},
+       "auth": {"type": "oauth2"}}}
+  ]
+}`
+
+// Test 'ImportPostmanCollection' method
+func TestImportPostmanCollection(t *testing.T) {
+	output, warnings, error := ImportPostmanCollection([]byte(postmanCollectionJSON))
+
+	if error != nil {
+		t.Fatal("Expected nil, got ", error)
+	}
+	if len(output.Data) != 2 {
+		t.Fatal("Expected len(2), got ", len(output.Data))
+	}
+	if len(warnings) != 2 {
+		t.Error("Expected 2 warnings (script, oauth2), got ", warnings)
+	}
+
+	get, _ := output.Find("GET", "{baseurl}/ticket/{id}")
+	if get.ProjectName != "Tickets" || get.Alias != "Get ticket" {
+		t.Error("Expected 'Tickets' / 'Get ticket', got ", get.ProjectName, get.Alias)
+	}
+	if get.PathParams["{id}"] != "42" || get.MapRequestHeaderKeyValue["Authorization"] != "Bearer {token}" {
+		t.Error("Expected path variable & bearer header, got ", get.PathParams, get.MapRequestHeaderKeyValue)
+	}
+	if get.IsHeaderEnabled("X-Off") || get.GetHeaders()["X-Off"] != "1" {
+		t.Error("Expected 'X-Off' disabled header, got ", get.GetHeaders())
+	}
+
+	post, _ := output.Find("POST", "{baseurl}/ticket")
+	if post.ProjectName != "Jira" || post.Body != `{"name": "{name}"}` {
+		t.Error("Expected 'Jira' project with body, got ", post.ProjectName, post.Body)
+	}
+
+	if output.Context.GetAllKeyValue("default")["{baseurl}"] != "https://jira.io" {
+		t.Error("Expected '{baseurl}' variable, got ", output.Context.Env)
+	}
+}
+
+// Test 'ImportPostmanEnvironment' method
+func TestImportPostmanEnvironment(t *testing.T) {
+	data := `{"name": "Prod", "values": [{"key": "token", "value": "abc", "enabled": true}, {"key": "off", "value": "1", "enabled": false}]}`
+
+	context, warnings, error := ImportPostmanEnvironment([]byte(data))
+
+	if error != nil || len(warnings) != 1 {
+		t.Fatal("Expected 1 warning, got ", error, warnings)
+	}
+	if context.GetAllKeyValue("prod")["{token}"] != "abc" {
+		t.Error("Expected '{token}' variable, got ", context.Env)
+	}
+}
+
+// Test 'ImportPostmanCollection' method with several items sharing the same method & URL (none is dropped)
+func TestImportPostmanCollectionSameRequest(t *testing.T) {
+	collection := `{
+  "info": {"name": "Shop"},
+  "item": [
+    {"name": "Orders", "item": [
+      {"name": "Create order", "request": {"method": "POST", "url": "http://shop.io/orders", "body": {"mode": "raw", "raw": "{\"qty\":1}"}}},
+      {"name": "Create order", "request": {"method": "POST", "url": "http://shop.io/orders", "body": {"mode": "raw", "raw": "{\"qty\":0}"}}}
+    ]},
+    {"name": "Admin", "item": [
+      {"name": "Create order", "request": {"method": "POST", "url": "http://shop.io/orders", "body": {"mode": "raw", "raw": "{\"qty\":9}"}}}
+    ]}
+  ]
+}`
+	output, warnings, error := ImportPostmanCollection([]byte(collection))
+
+	if error != nil {
+		t.Fatal("Expected nil, got ", error)
+	}
+	if len(output.Data) != 3 {
+		t.Fatal("Expected len(3), got ", len(output.Data))
+	}
+	bodies := make(map[string]bool)
+	for _, data := range output.Data {
+		bodies[data.Body] = true
+	}
+	if !bodies[`{"qty":1}`] || !bodies[`{"qty":0}`] || !bodies[`{"qty":9}`] {
+		t.Error("Expected all the bodies, got ", output.Data)
+	}
+	if len(warnings) == 0 {
+		t.Error("Expected a duplicate name warning, got ", warnings)
+	}
+	for _, data := range output.Data {
+		if found, error := output.FindByAlias(data.ProjectName, data.Alias); error != nil || found.Body != data.Body {
+			t.Error("Expected unique project/alias, got ", data.ProjectName, data.Alias)
+		}
+	}
+}
+
+// Test 'ImportPostmanCollection' method keeps the order of the collection (the project runner follows it)
+func TestImportPostmanCollectionOrder(t *testing.T) {
+	collection := `{
+  "info": {"name": "Shop"},
+  "item": [
+    {"name": "login", "request": {"method": "POST", "url": "http://shop.io/login"}},
+    {"name": "profile", "request": {"method": "GET", "url": "http://shop.io/me"}},
+    {"name": "logout", "request": {"method": "POST", "url": "http://shop.io/logout"}}
+  ]
+}`
+	imported, _, error := ImportPostmanCollection([]byte(collection))
+	if error != nil {
+		t.Fatal("Expected nil, got ", error)
+	}
+
+	aliases := func(data []models.MakeRequestData) []string {
+		var values []string
+		for _, value := range data {
+			values = append(values, value.Alias)
+		}
+		return values
+	}
+	if actual := aliases(imported.Data); !reflect.DeepEqual(actual, []string{"login", "profile", "logout"}) {
+		t.Error("Expected [login profile logout], got ", actual)
+	}
+
+	output := models.Output{Data: []models.MakeRequestData{models.SimpleMakeRequestData("GET", "http://shop.io/health", "Shop", "health")}}
+	output.Merge(imported)
+	_, dataAPIsByProjectName := output.SortDataAPIsByProjectName()
+	if actual := aliases(dataAPIsByProjectName["Shop"]); !reflect.DeepEqual(actual, []string{"health", "login", "profile", "logout"}) {
+		t.Error("Expected [health login profile logout], got ", actual)
+	}
+}
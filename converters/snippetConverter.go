@@ -0,0 +1,124 @@
+package converters
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/joakim-ribier/gttp/models"
+)
+
+// Represents the available snippet formats
+const (
+	SnippetCurl   = "curl"
+	SnippetHTTPie = "HTTPie"
+	SnippetWget   = "wget"
+	SnippetGo     = "Go (net/http)"
+)
+
+// SnippetFormats lists the available snippet formats
+var SnippetFormats = []string{SnippetCurl, SnippetHTTPie, SnippetWget, SnippetGo}
+
+// ToSnippet converts the resolved request to a runnable snippet of the @format
+func ToSnippet(format string, request models.ResolvedRequest) string {
+	switch format {
+	case SnippetHTTPie:
+		return ToHTTPie(request)
+	case SnippetWget:
+		return ToWget(request)
+	case SnippetGo:
+		return ToGo(request)
+	default:
+		return ToCurl(request)
+	}
+}
+
+// ToCurl converts the resolved request to a curl command line
+func ToCurl(request models.ResolvedRequest) string {
+	method := "-X " + request.Method.String()
+	if request.Method == "HEAD" {
+		// "-X HEAD" waits for a response body which never comes
+		method = "-I"
+	}
+	lines := []string{"curl " + method + " " + shellQuote(request.URL.String())}
+	for _, header := range snippetHeaders(request) {
+		lines = append(lines, "-H "+shellQuote(header))
+	}
+	if request.Body != "" {
+		lines = append(lines, "--data-raw "+shellQuote(request.Body))
+	}
+	return strings.Join(lines, " \\\n  ")
+}
+
+// ToHTTPie converts the resolved request to a HTTPie command line
+func ToHTTPie(request models.ResolvedRequest) string {
+	lines := []string{"http " + request.Method.String() + " " + shellQuote(request.URL.String())}
+	for _, header := range snippetHeaders(request) {
+		lines = append(lines, shellQuote(header))
+	}
+	command := strings.Join(lines, " \\\n  ")
+	if request.Body != "" {
+		return "printf '%s' " + shellQuote(request.Body) + " | " + command
+	}
+	return command
+}
+
+// ToWget converts the resolved request to a wget command line
+func ToWget(request models.ResolvedRequest) string {
+	lines := []string{"wget --method=" + request.Method.String()}
+	for _, header := range snippetHeaders(request) {
+		lines = append(lines, "--header="+shellQuote(header))
+	}
+	if request.Body != "" {
+		lines = append(lines, "--body-data="+shellQuote(request.Body))
+	}
+	lines = append(lines, "-O - "+shellQuote(request.URL.String()))
+	return strings.Join(lines, " \\\n  ")
+}
+
+// ToGo converts the resolved request to a Go "net/http" program
+func ToGo(request models.ResolvedRequest) string {
+	var sb strings.Builder
+	sb.WriteString("package main\n\n")
+	sb.WriteString("import (\n\t\"fmt\"\n\t\"io\"\n\t\"net/http\"\n")
+	if request.Body != "" {
+		sb.WriteString("\t\"strings\"\n")
+	}
+	sb.WriteString(")\n\n")
+	sb.WriteString("func main() {\n")
+	if request.Body != "" {
+		sb.WriteString("\tbody := strings.NewReader(" + strconv.Quote(request.Body) + ")\n")
+		sb.WriteString("\treq, err := http.NewRequest(" + strconv.Quote(request.Method.String()) + ", " + strconv.Quote(request.URL.String()) + ", body)\n")
+	} else {
+		sb.WriteString("\treq, err := http.NewRequest(" + strconv.Quote(request.Method.String()) + ", " + strconv.Quote(request.URL.String()) + ", nil)\n")
+	}
+	sb.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n")
+	for _, header := range snippetHeaders(request) {
+		index := strings.Index(header, ":")
+		sb.WriteString("\treq.Header.Set(" + strconv.Quote(header[:index]) + ", " + strconv.Quote(strings.TrimSpace(header[index+1:])) + ")\n")
+	}
+	sb.WriteString("\n\tresp, err := http.DefaultClient.Do(req)\n")
+	sb.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n")
+	sb.WriteString("\tdefer resp.Body.Close()\n\n")
+	sb.WriteString("\tdata, _ := io.ReadAll(resp.Body)\n")
+	sb.WriteString("\tfmt.Println(resp.Status)\n")
+	sb.WriteString("\tfmt.Println(string(data))\n")
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// snippetHeaders returns the sorted "Key: Value" headers (content type included)
+func snippetHeaders(request models.ResolvedRequest) []string {
+	var headers []string
+	if _, exists := request.Headers["Content-Type"]; !exists && request.ContentType != "" {
+		headers = append(headers, "Content-Type: "+request.ContentType)
+	}
+	for _, key := range request.Headers.ToSortedKeys() {
+		headers = append(headers, key+": "+request.Headers[key])
+	}
+	return headers
+}
+
+// shellQuote quotes the @value for a POSIX shell
+func shellQuote(value string) string {
+	return "'" + strings.Replace(value, "'", `'\''`, -1) + "'"
+}
@@ -0,0 +1,51 @@
+package converters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/joakim-ribier/gttp/core"
+	"github.com/joakim-ribier/gttp/models"
+)
+
+// Test 'ToCurl' method
+func TestToCurlCanBeImported(t *testing.T) {
+	mrd := models.NewMakeRequestData("POST", "http://{host}/users/{id}", core.StringMap{"{id}": "1", "X-Token": "{token}"}, `{"name":"bob's"}`, "application/json", "", "")
+	request := mrd.Resolve(map[string]string{"{host}": "localhost", "{token}": "secret"})
+
+	actual, _, error := ParseCurl(ToCurl(request))
+
+	if error != nil {
+		t.Fatal("Expected nil, got ", error)
+	}
+	if actual.Method != "POST" || actual.URL != "http://localhost/users/1" || actual.Body != mrd.Body {
+		t.Error("Expected 'POST http://localhost/users/1' with body, got ", actual.Method, actual.URL, actual.Body)
+	}
+	if actual.MapRequestHeaderKeyValue["X-Token"] != "secret" || actual.ContentType != "application/json" {
+		t.Error("Expected resolved headers, got ", actual.MapRequestHeaderKeyValue, actual.ContentType)
+	}
+}
+
+// Test 'ToCurl' method with the HEAD method
+func TestToCurlHead(t *testing.T) {
+	request := models.NewMakeRequestData("HEAD", "http://localhost/users", core.StringMap{}, "", "", "", "").Resolve(map[string]string{})
+
+	expected := "curl -I 'http://localhost/users'"
+	if actual := ToCurl(request); actual != expected {
+		t.Error("Expected ", expected, ", got ", actual)
+	}
+	if actual, _, error := ParseCurl(ToCurl(request)); error != nil || actual.Method != "HEAD" {
+		t.Error("Expected 'HEAD', got ", actual.Method, error)
+	}
+}
+
+// Test 'ToGo' method
+func TestToGo(t *testing.T) {
+	request := models.ResolvedRequest{Method: "GET", URL: "http://localhost", Headers: core.StringMap{"Accept": "*/*"}}
+
+	actual := ToGo(request)
+
+	if !strings.Contains(actual, `http.NewRequest("GET", "http://localhost", nil)`) || !strings.Contains(actual, `req.Header.Set("Accept", "*/*")`) {
+		t.Error("Expected Go snippet, got ", actual)
+	}
+}
@@ -0,0 +1,25 @@
+package core
+
+import (
+	"sort"
+	"strings"
+)
+
+// ReplaceContext replaces the {variable} parts of the @value by their @contextValues
+// (the keys which are not "{variable}" are ignored, the variables are replaced in the keys order)
+func ReplaceContext(value string, contextValues map[string]string) string {
+	if !strings.Contains(value, "{") {
+		return value
+	}
+	keys := make([]string, 0, len(contextValues))
+	for key := range contextValues {
+		if strings.HasPrefix(key, "{") && strings.HasSuffix(key, "}") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value = strings.Replace(value, key, contextValues[key], -1)
+	}
+	return value
+}
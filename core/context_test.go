@@ -0,0 +1,36 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Test 'ReplaceContext' method
+func TestReplaceContext(t *testing.T) {
+	contextValues := map[string]string{"{host}": "localhost", "{id}": "42", "token": "not a variable"}
+
+	tests := map[string]string{
+		"http://{host}/users/{id}": "http://localhost/users/42",
+		"Bearer {token}":           "Bearer {token}",
+		"{unknown}":                "{unknown}",
+		"token":                    "token",
+		"{id}{id}":                 "4242",
+	}
+	for value, expected := range tests {
+		if actual := ReplaceContext(value, contextValues); actual != expected {
+			t.Error("Expected ", expected, ", got ", actual)
+		}
+	}
+}
+
+// Test 'StringMap.ReplaceContext' method (whole value or {variable} parts)
+func TestStringMapReplaceContext(t *testing.T) {
+	values := StringMap{"Authorization": "Bearer {token}", "X-Id": "{id}", "X-Value": "value"}
+
+	actual := values.ReplaceContext(map[string]string{"{token}": "abc", "{id}": "42", "value": "replaced"})
+
+	expected := StringMap{"Authorization": "Bearer abc", "X-Id": "42", "X-Value": "replaced"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Error("Expected ", expected, ", got ", actual)
+	}
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Represents the parameters of the passphrase encryption
+const (
+	cryptoSaltSize   = 16
+	cryptoKeySize    = 32
+	cryptoIterations = 200000
+)
+
+// ErrWrongPassphrase is returned when the data can't be decrypted with the passphrase
+var ErrWrongPassphrase = errors.New("wrong passphrase or corrupted data")
+
+// Encrypt encrypts the @data with the @passphrase (AES-256-GCM, the key is derived with PBKDF2-SHA256),
+// the result contains the salt, the nonce & the encrypted data
+func Encrypt(data []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, cryptoSaltSize)
+	if _, error := rand.Read(salt); error != nil {
+		return nil, error
+	}
+	gcm, error := newGCM(passphrase, salt)
+	if error != nil {
+		return nil, error
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, error := rand.Read(nonce); error != nil {
+		return nil, error
+	}
+
+	result := append(salt, nonce...)
+	return gcm.Seal(result, nonce, data, nil), nil
+}
+
+// Decrypt decrypts the @data encrypted by Encrypt with the same @passphrase
+func Decrypt(data []byte, passphrase string) ([]byte, error) {
+	if len(data) < cryptoSaltSize {
+		return nil, ErrWrongPassphrase
+	}
+	gcm, error := newGCM(passphrase, data[:cryptoSaltSize])
+	if error != nil {
+		return nil, error
+	}
+	data = data[cryptoSaltSize:]
+	if len(data) < gcm.NonceSize() {
+		return nil, ErrWrongPassphrase
+	}
+
+	value, error := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if error != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return value, nil
+}
+
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	block, error := aes.NewCipher(pbkdf2.Key([]byte(passphrase), salt, cryptoIterations, cryptoKeySize, sha256.New))
+	if error != nil {
+		return nil, error
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,24 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	data, error := Encrypt([]byte(`{"dev":{"{token}":"abc"}}`), "my passphrase")
+	if error != nil {
+		t.Fatal("Expected nil, got ", error)
+	}
+
+	value, error := Decrypt(data, "my passphrase")
+	if error != nil || string(value) != `{"dev":{"{token}":"abc"}}` {
+		t.Error("Expected decrypted data, got ", string(value), error)
+	}
+
+	if _, error := Decrypt(data, "wrong"); error != ErrWrongPassphrase {
+		t.Error("Expected ErrWrongPassphrase, got ", error)
+	}
+	if _, error := Decrypt(data[:10], "my passphrase"); error != ErrWrongPassphrase {
+		t.Error("Expected ErrWrongPassphrase, got ", error)
+	}
+}
@@ -0,0 +1,267 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var jsonPathIdentifierRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_\-]*$`)
+
+// jsonPathSegment represents a step of a JSONPath expression
+type jsonPathSegment struct {
+	key       string
+	index     int
+	isIndex   bool
+	wildcard  bool
+	recursive bool
+}
+
+// JSONPath evaluates the @path expression on the json @data,
+// supported syntax: $.key, $['key'], $[0], $[-1], $.*, $[*] and $..key (the "$" is optional).
+// The result is a slice of all the matching values if the expression contains a wildcard or a recursive descent.
+func JSONPath(data []byte, path string) (interface{}, error) {
+	value, error := decodeJSON(data)
+	if error != nil {
+		return nil, errors.New("invalid json: " + error.Error())
+	}
+	return JSONPathValue(value, path)
+}
+
+// decodeJSON decodes the json @data, the numbers are kept as json.Number (big ids are not rounded)
+func decodeJSON(data []byte) (interface{}, error) {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if error := decoder.Decode(&value); error != nil {
+		return nil, error
+	}
+	if _, error := decoder.Token(); error != io.EOF {
+		return nil, errors.New("invalid character after top-level value")
+	}
+	return value, nil
+}
+
+// JSONPathValue evaluates the @path expression on the decoded json @value (see JSONPath)
+func JSONPathValue(value interface{}, path string) (interface{}, error) {
+	segments, error := parseJSONPath(path)
+	if error != nil {
+		return nil, error
+	}
+
+	definite := true
+	nodes := []interface{}{value}
+	for _, segment := range segments {
+		if segment.wildcard || segment.recursive {
+			definite = false
+		}
+		var next []interface{}
+		for _, node := range nodes {
+			next = append(next, segment.apply(node)...)
+		}
+		nodes = next
+	}
+
+	if !definite {
+		if nodes == nil {
+			nodes = []interface{}{}
+		}
+		return nodes, nil
+	}
+	if len(nodes) == 0 {
+		return nil, errors.New("no value for '" + path + "'")
+	}
+	return nodes[0], nil
+}
+
+// JSONQuery evaluates the @expression on the json @data, the expression is a JSONPath ("$.items[*].id")
+// or a jq-like path (".items[].id", ".items[] | .id"), an empty expression (or "." / "$") returns the whole document.
+func JSONQuery(data []byte, expression string) (interface{}, error) {
+	path, error := JQToJSONPath(expression)
+	if error != nil {
+		return nil, error
+	}
+	return JSONPath(data, path)
+}
+
+// JQToJSONPath converts a jq-like path @expression to a JSONPath (a JSONPath expression is returned as is)
+func JQToJSONPath(expression string) (string, error) {
+	expression = strings.TrimSpace(expression)
+	if !strings.HasPrefix(expression, ".") {
+		return expression, nil
+	}
+
+	path := "$"
+	for _, filter := range strings.Split(expression, "|") {
+		filter = strings.TrimSpace(filter)
+		if !strings.HasPrefix(filter, ".") {
+			return "", errors.New("unsupported jq filter '" + filter + "'")
+		}
+		if filter == "." {
+			continue
+		}
+		filter = strings.Replace(filter, "[]", "[*]", -1)
+		filter = strings.Replace(filter, ".[", "[", -1)
+		if !strings.HasPrefix(filter, "[") && !strings.HasPrefix(filter, ".") {
+			filter = "." + filter
+		}
+		path += filter
+	}
+	return path, nil
+}
+
+// JSONValueToString formats a json value (strings are not quoted, objects and arrays are serialized)
+func JSONValueToString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return "null"
+	case json.Number:
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		bytes, _ := json.Marshal(v)
+		return string(bytes)
+	}
+}
+
+// JSONPathChild returns the JSONPath of the @key of the object at @path
+// (bracket notation if the key is not a simple identifier)
+func JSONPathChild(path string, key string) string {
+	if jsonPathIdentifierRegex.MatchString(key) {
+		return path + "." + key
+	}
+	return path + "['" + key + "']"
+}
+
+// JSONPathItem returns the JSONPath of the @index item of the array at @path
+func JSONPathItem(path string, index int) string {
+	return path + "[" + strconv.Itoa(index) + "]"
+}
+
+func (segment jsonPathSegment) apply(node interface{}) []interface{} {
+	if segment.recursive {
+		var values []interface{}
+		var walk func(node interface{})
+		walk = func(node interface{}) {
+			values = append(values, jsonPathSegment{key: segment.key, wildcard: segment.wildcard}.apply(node)...)
+			switch v := node.(type) {
+			case map[string]interface{}:
+				for _, key := range sortedKeys(v) {
+					walk(v[key])
+				}
+			case []interface{}:
+				for _, child := range v {
+					walk(child)
+				}
+			}
+		}
+		walk(node)
+		return values
+	}
+
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if segment.wildcard {
+			var values []interface{}
+			for _, key := range sortedKeys(v) {
+				values = append(values, v[key])
+			}
+			return values
+		}
+		if child, exists := v[segment.key]; exists && !segment.isIndex {
+			return []interface{}{child}
+		}
+	case []interface{}:
+		if segment.wildcard {
+			return v
+		}
+		index := segment.index
+		if index < 0 {
+			index = len(v) + index
+		}
+		if segment.isIndex && index >= 0 && index < len(v) {
+			return []interface{}{v[index]}
+		}
+	}
+	return nil
+}
+
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	invalid := func() ([]jsonPathSegment, error) {
+		return nil, errors.New("invalid JSONPath '" + path + "'")
+	}
+
+	value := strings.TrimSpace(path)
+	value = strings.TrimPrefix(value, "$")
+	if value != "" && value[0] != '.' && value[0] != '[' {
+		value = "." + value
+	}
+
+	var segments []jsonPathSegment
+	for value != "" {
+		recursive := false
+		switch {
+		case strings.HasPrefix(value, ".."):
+			recursive = true
+			value = value[2:]
+			if strings.HasPrefix(value, "[") {
+				break
+			}
+			fallthrough
+		case value[0] == '.':
+			value = strings.TrimPrefix(value, ".")
+			end := strings.IndexAny(value, ".[")
+			if end == -1 {
+				end = len(value)
+			}
+			key := value[:end]
+			if key == "" {
+				return invalid()
+			}
+			segments = append(segments, jsonPathSegment{key: key, wildcard: key == "*", recursive: recursive})
+			value = value[end:]
+			continue
+		}
+
+		if !strings.HasPrefix(value, "[") {
+			return invalid()
+		}
+		end := strings.Index(value, "]")
+		if end == -1 {
+			return invalid()
+		}
+		content := strings.TrimSpace(value[1:end])
+		value = value[end+1:]
+
+		switch {
+		case content == "*":
+			segments = append(segments, jsonPathSegment{wildcard: true, recursive: recursive})
+		case len(content) >= 2 && (content[0] == '\'' || content[0] == '"') && content[len(content)-1] == content[0]:
+			segments = append(segments, jsonPathSegment{key: content[1 : len(content)-1], recursive: recursive})
+		default:
+			index, error := strconv.Atoi(content)
+			if error != nil {
+				return invalid()
+			}
+			segments = append(segments, jsonPathSegment{index: index, isIndex: true, recursive: recursive})
+		}
+	}
+	return segments, nil
+}
+
+func sortedKeys(value map[string]interface{}) []string {
+	keys := make(StringMap)
+	for key := range value {
+		keys[key] = ""
+	}
+	return keys.ToSortedKeys()
+}
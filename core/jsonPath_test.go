@@ -0,0 +1,121 @@
+package core
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const jsonPathData = `{
+	"token": "abc",
+	"user": {"id": 42, "roles": ["admin", "dev"], "active": true},
+	"items": [{"id": 1, "name": "a"}, {"id": 2, "name": "b"}],
+	"my key": null
+}`
+
+func TestJSONPath(t *testing.T) {
+	values := map[string]string{
+		"$.token":             "abc",
+		"token":               "abc",
+		"$.user.id":           "42",
+		"$.user.roles[1]":     "dev",
+		"$.user.roles[-1]":    "dev",
+		"$['user']['active']": "true",
+		"$.items[0].name":     "a",
+		"$[\"my key\"]":       "null",
+		"$.user.roles":        `["admin","dev"]`,
+	}
+	for path, expected := range values {
+		value, error := JSONPath([]byte(jsonPathData), path)
+		if error != nil {
+			t.Error("Expected ", expected, " for ", path, ", got ", error)
+			continue
+		}
+		if actual := JSONValueToString(value); actual != expected {
+			t.Error("Expected ", expected, " for ", path, ", got ", actual)
+		}
+	}
+}
+
+func TestJSONPathWildcard(t *testing.T) {
+	value, _ := JSONPath([]byte(jsonPathData), "$.items[*].id")
+	if !reflect.DeepEqual(value, []interface{}{json.Number("1"), json.Number("2")}) {
+		t.Error("Expected [1 2], got ", value)
+	}
+
+	value, _ = JSONPath([]byte(jsonPathData), "$..id")
+	if !reflect.DeepEqual(value, []interface{}{json.Number("1"), json.Number("2"), json.Number("42")}) {
+		t.Error("Expected [1 2 42], got ", value)
+	}
+
+	value, _ = JSONPath([]byte(jsonPathData), "$.unknown[*]")
+	if !reflect.DeepEqual(value, []interface{}{}) {
+		t.Error("Expected [], got ", value)
+	}
+}
+
+func TestJSONPathBigNumber(t *testing.T) {
+	data := []byte(`{"id": 9007199254740993, "amount": 1.10, "big": 1e21}`)
+	for path, expected := range map[string]string{"$.id": "9007199254740993", "$.amount": "1.10", "$.big": "1e21"} {
+		value, error := JSONPath(data, path)
+		if error != nil {
+			t.Error("Expected ", expected, " for ", path, ", got ", error)
+		} else if actual := JSONValueToString(value); actual != expected {
+			t.Error("Expected ", expected, " for ", path, ", got ", actual)
+		}
+	}
+}
+
+func TestJSONPathError(t *testing.T) {
+	for _, path := range []string{"$.unknown", "$.items[5]", "$.items[", "$.items[a]", "$.token.", "not json"} {
+		data := []byte(jsonPathData)
+		if path == "not json" {
+			data = []byte("{")
+		}
+		if _, error := JSONPath(data, path); error == nil {
+			t.Error("Expected error for ", path, ", got nil")
+		}
+	}
+}
+
+func TestJSONPathChild(t *testing.T) {
+	path := JSONPathItem(JSONPathChild(JSONPathChild("$", "data"), "user list"), 1)
+	if path != "$.data['user list'][1]" {
+		t.Fatal("Expected $.data['user list'][1], got ", path)
+	}
+
+	value, error := JSONPath([]byte(`{"data": {"user list": [{}, "b"]}}`), path)
+	if error != nil || value != "b" {
+		t.Error("Expected 'b', got ", value, error)
+	}
+}
+
+func TestJSONQuery(t *testing.T) {
+	data := []byte(`{"items": [{"id": 1, "tags": ["a"]}, {"id": 2, "tags": []}], "my key": "v"}`)
+
+	values := map[string]string{
+		"":                    `{"items":[{"id":1,"tags":["a"]},{"id":2,"tags":[]}],"my key":"v"}`,
+		".":                   `{"items":[{"id":1,"tags":["a"]},{"id":2,"tags":[]}],"my key":"v"}`,
+		".items[0].id":        `1`,
+		".items[].id":         `[1,2]`,
+		".items[] | .id":      `[1,2]`,
+		".items | .[1] | .id": `2`,
+		`.["my key"]`:         `"v"`,
+		"$.items[*].tags[0]":  `["a"]`,
+		"$..id":               `[1,2]`,
+	}
+	for expression, expected := range values {
+		value, error := JSONQuery(data, expression)
+		if error != nil {
+			t.Error("Expected nil for '", expression, "', got ", error)
+			continue
+		}
+		if actual, _ := json.Marshal(value); string(actual) != expected {
+			t.Error("Expected ", expected, " for '", expression, "', got ", string(actual))
+		}
+	}
+
+	if _, error := JSONQuery(data, ".items | length"); error == nil {
+		t.Error("Expected error, got nil")
+	}
+}
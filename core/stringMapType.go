@@ -1,6 +1,8 @@
 package core
 
-import "sort"
+import (
+	"sort"
+)
 
 // StringMap map[string]string type
 type StringMap map[string]string
@@ -15,13 +17,15 @@ func (sMap StringMap) ToSortedKeys() []string {
 	return tab
 }
 
-// ReplaceContext replaces all intial values by the context values
+// ReplaceContext replaces all intial values (or the {variable} parts of the values) by the context values
 func (sMap StringMap) ReplaceContext(mapKeysValues map[string]string) StringMap {
 	new := make(map[string]string)
 	for key, value := range sMap {
 		new[key] = value
 		if val, ok := mapKeysValues[value]; ok {
 			new[key] = val
+		} else {
+			new[key] = ReplaceContext(value, mapKeysValues)
 		}
 	}
 	return new
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
+	"time"
 )
 
 // HTTPClient is object which contains *http data.
@@ -13,7 +15,34 @@ type HTTPClient struct {
 	Response        *HTTPResponseClient
 	Body            []byte
 	HeadersRequest  map[string]string
-	HeadersResponse map[string]string
+	HeadersResponse http.Header
+	Timing          Timing
+	TLS             *TLSInfo
+	Proxy           string
+}
+
+// Timing contains the duration of each phase of the request (recorded with httptrace)
+type Timing struct {
+	DNSLookup        time.Duration
+	TCPConnection    time.Duration
+	TLSHandshake     time.Duration
+	TimeToFirstByte  time.Duration
+	ContentTransfer  time.Duration
+	Total            time.Duration
+	ConnectionReused bool
+}
+
+// String returns the timing as a readable string
+func (t Timing) String() string {
+	round := func(d time.Duration) string {
+		return d.Round(time.Microsecond * 100).String()
+	}
+	return "dns=" + round(t.DNSLookup) +
+		" connect=" + round(t.TCPConnection) +
+		" tls=" + round(t.TLSHandshake) +
+		" ttfb=" + round(t.TimeToFirstByte) +
+		" transfer=" + round(t.ContentTransfer) +
+		" total=" + round(t.Total)
 }
 
 // HTTPRequestClient struct
@@ -27,15 +56,11 @@ type HTTPRequestClient struct {
 
 // HTTPResponseClient struct
 type HTTPResponseClient struct {
-	Response       *http.Response
-	Status         string
-	StatusCode     string
-	HTTP           string
-	Contentlength  string
-	ContentType    string
-	Date           string
-	Referrerpolicy string
-	Connection     string
+	Response    *http.Response
+	Status      string
+	StatusCode  string
+	HTTP        string
+	ContentType string
 }
 
 // NewHTTPClient returns an instance of HTTPClient object which contains *httpResponse and body.
@@ -45,7 +70,8 @@ func NewHTTPClient(response *http.Response, data []byte) *HTTPClient {
 		Response:        newHTTPResponseClient(response),
 		Body:            data,
 		HeadersRequest:  make(map[string]string),
-		HeadersResponse: make(map[string]string),
+		HeadersResponse: make(http.Header),
+		TLS:             newTLSInfo(response.TLS),
 	}
 }
 
@@ -71,15 +97,11 @@ func newHTTPRequestClient(response *http.Response) *HTTPRequestClient {
 
 func newHTTPResponseClient(response *http.Response) *HTTPResponseClient {
 	return &HTTPResponseClient{
-		Response:       response,
-		Status:         response.Status,
-		StatusCode:     strconv.Itoa(response.StatusCode),
-		HTTP:           strconv.Itoa(response.ProtoMajor) + "." + strconv.Itoa(response.ProtoMinor),
-		Contentlength:  strconv.FormatInt(response.ContentLength, 10),
-		ContentType:    response.Header.Get("content-type"),
-		Date:           response.Header.Get("date"),
-		Referrerpolicy: response.Header.Get("referrer-policy"),
-		Connection:     response.Header.Get("connection"),
+		Response:    response,
+		Status:      response.Status,
+		StatusCode:  strconv.Itoa(response.StatusCode),
+		HTTP:        strconv.Itoa(response.ProtoMajor) + "." + strconv.Itoa(response.ProtoMinor),
+		ContentType: response.Header.Get("content-type"),
 	}
 }
 
@@ -88,11 +110,21 @@ func (client *HTTPClient) headerRequest(key string, value string) *HTTPClient {
 	return client
 }
 
-func (client *HTTPClient) headerResponse(key string, value string) *HTTPClient {
-	client.HeadersResponse[key] = value
+func (client *HTTPClient) headerResponse(key string, values []string) *HTTPClient {
+	client.HeadersResponse[key] = append([]string{}, values...)
 	return client
 }
 
+// SortedHeadersResponse returns the names of the response headers sorted alphabetically
+func (client *HTTPClient) SortedHeadersResponse() []string {
+	keys := make([]string, 0, len(client.HeadersResponse))
+	for key := range client.HeadersResponse {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func (client *HTTPClient) withHeaderData(logger func(message string, mode string)) *HTTPClient {
 	response := client.Response.Response
 
@@ -101,7 +133,12 @@ func (client *HTTPClient) withHeaderData(logger func(message string, mode string
 	}
 
 	for k, v := range response.Header {
-		client = client.headerResponse(k, v[0])
+		client = client.headerResponse(k, v)
+	}
+
+	// The transfer encoding is removed from the header by the http package
+	if len(response.TransferEncoding) > 0 && client.HeadersResponse.Get("Transfer-Encoding") == "" {
+		client = client.headerResponse("Transfer-Encoding", response.TransferEncoding)
 	}
 
 	logger("Request header: "+fmt.Sprintf("%s", response.Request.Header), "debug")
@@ -0,0 +1,161 @@
+package httpclient
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Represents the authentication schemes
+const (
+	AuthBasic        = "basic"
+	AuthBearer       = "bearer"
+	AuthAPIKeyHeader = "apikey-header"
+	AuthAPIKeyQuery  = "apikey-query"
+	AuthDigest       = "digest"
+)
+
+// Auth contains the authentication of the request (an empty scheme means no authentication)
+type Auth struct {
+	Scheme   string
+	Username string
+	Password string
+	Token    string
+	Key      string
+}
+
+// apply sets the authentication of the @request (the digest one needs the challenge of the server, see digestAuthorization)
+func (a Auth) apply(request *http.Request) {
+	switch a.Scheme {
+	case AuthBasic:
+		request.SetBasicAuth(a.Username, a.Password)
+	case AuthBearer:
+		request.Header.Set("Authorization", "Bearer "+a.Token)
+	case AuthAPIKeyHeader:
+		request.Header.Set(a.Key, a.Token)
+	case AuthAPIKeyQuery:
+		param := url.QueryEscape(a.Key) + "=" + url.QueryEscape(a.Token)
+		if request.URL.RawQuery == "" {
+			request.URL.RawQuery = param
+		} else {
+			request.URL.RawQuery += "&" + param
+		}
+	}
+}
+
+// digestChallenge returns the digest challenge of the "WWW-Authenticate" @header values (empty if none)
+func digestChallenge(header http.Header) string {
+	for _, value := range header.Values("WWW-Authenticate") {
+		if len(value) > 7 && strings.EqualFold(value[:7], "Digest ") {
+			return value[7:]
+		}
+	}
+	return ""
+}
+
+// digestAuthorization computes the "Authorization" header value which answers the digest @challenge (RFC 7616, qop "auth" only)
+func (a Auth) digestAuthorization(method string, uri string, challenge string) (string, error) {
+	params := parseAuthParams(challenge)
+
+	var newHash func() hash.Hash
+	algorithm := params["algorithm"]
+	switch strings.ToUpper(strings.TrimSuffix(strings.ToUpper(algorithm), "-SESS")) {
+	case "", "MD5":
+		newHash = md5.New
+	case "SHA-256":
+		newHash = sha256.New
+	default:
+		return "", errors.New("unsupported digest algorithm '" + algorithm + "'")
+	}
+	h := func(value string) string {
+		digest := newHash()
+		digest.Write([]byte(value))
+		return hex.EncodeToString(digest.Sum(nil))
+	}
+
+	qop := ""
+	if value, exists := params["qop"]; exists {
+		for _, option := range strings.Split(value, ",") {
+			if strings.TrimSpace(option) == "auth" {
+				qop = "auth"
+			}
+		}
+		if qop == "" {
+			return "", errors.New("unsupported digest qop '" + value + "'")
+		}
+	}
+
+	cnonceBytes := make([]byte, 16)
+	rand.Read(cnonceBytes)
+	cnonce := hex.EncodeToString(cnonceBytes)
+	nc := "00000001"
+
+	ha1 := h(a.Username + ":" + params["realm"] + ":" + a.Password)
+	if strings.HasSuffix(strings.ToUpper(algorithm), "-SESS") {
+		ha1 = h(ha1 + ":" + params["nonce"] + ":" + cnonce)
+	}
+	ha2 := h(method + ":" + uri)
+
+	var response string
+	if qop == "" {
+		response = h(ha1 + ":" + params["nonce"] + ":" + ha2)
+	} else {
+		response = h(ha1 + ":" + params["nonce"] + ":" + nc + ":" + cnonce + ":" + qop + ":" + ha2)
+	}
+
+	values := []string{
+		`username="` + a.Username + `"`,
+		`realm="` + params["realm"] + `"`,
+		`nonce="` + params["nonce"] + `"`,
+		`uri="` + uri + `"`,
+		`response="` + response + `"`,
+	}
+	if algorithm != "" {
+		values = append(values, "algorithm="+algorithm)
+	}
+	if qop != "" {
+		values = append(values, "qop="+qop, "nc="+nc, `cnonce="`+cnonce+`"`)
+	}
+	if opaque, exists := params["opaque"]; exists {
+		values = append(values, `opaque="`+opaque+`"`)
+	}
+	return "Digest " + strings.Join(values, ", "), nil
+}
+
+// parseAuthParams parses the comma separated key=value (or key="value") params of an authentication header
+func parseAuthParams(value string) map[string]string {
+	params := make(map[string]string)
+	for value != "" {
+		value = strings.TrimLeft(value, " ,")
+		equal := strings.Index(value, "=")
+		if equal == -1 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(value[:equal]))
+		value = strings.TrimLeft(value[equal+1:], " ")
+
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end == -1 {
+				params[key] = value[1:]
+				break
+			}
+			params[key] = value[1 : end+1]
+			value = value[end+2:]
+		} else {
+			end := strings.Index(value, ",")
+			if end == -1 {
+				end = len(value)
+			}
+			params[key] = strings.TrimSpace(value[:end])
+			value = value[end:]
+		}
+	}
+	return params
+}
@@ -0,0 +1,98 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joakim-ribier/gttp/models/types"
+)
+
+func TestCallAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("Authorization") + "|" + r.Header.Get("X-API-Key") + "|" + r.URL.RawQuery))
+	}))
+	defer server.Close()
+
+	call := func(url string, auth Auth) string {
+		client, error := Call(context.Background(), "GET", types.URL(url), "text/plain", nil, nil, Options{Timeout: options.Timeout, Auth: auth}, noLog)
+		if error != nil {
+			t.Fatal("Expected nil, got ", error)
+		}
+		return string(client.Body)
+	}
+
+	values := map[string]Auth{
+		"Basic Ym9iOnNlY3JldA==||page=1": {Scheme: AuthBasic, Username: "bob", Password: "secret"},
+		"Bearer abc.def||page=1":         {Scheme: AuthBearer, Token: "abc.def"},
+		"|key-123|page=1":                {Scheme: AuthAPIKeyHeader, Key: "X-API-Key", Token: "key-123"},
+		"||page=1&api_key=a%26b":         {Scheme: AuthAPIKeyQuery, Key: "api_key", Token: "a&b"},
+		"||page=1":                       {},
+	}
+	for expected, auth := range values {
+		if actual := call(server.URL+"?page=1", auth); actual != expected {
+			t.Error("Expected ", expected, ", got ", actual)
+		}
+	}
+}
+
+func TestCallDigestAuth(t *testing.T) {
+	h := func(value string) string {
+		digest := md5.Sum([]byte(value))
+		return hex.EncodeToString(digest[:])
+	}
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		authorization := r.Header.Get("Authorization")
+		if len(authorization) < 7 || authorization[:7] != "Digest " {
+			w.Header().Set("WWW-Authenticate", `Digest realm="gttp", qop="auth,auth-int", nonce="abc123", opaque="xyz"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		params := parseAuthParams(authorization[7:])
+		ha1 := h("bob:gttp:secret")
+		ha2 := h(r.Method + ":" + r.URL.RequestURI())
+		expected := h(ha1 + ":abc123:" + params["nc"] + ":" + params["cnonce"] + ":" + params["qop"] + ":" + ha2)
+		if params["response"] != expected || params["uri"] != r.URL.RequestURI() || params["opaque"] != "xyz" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte("welcome " + params["username"]))
+	}))
+	defer server.Close()
+
+	auth := Auth{Scheme: AuthDigest, Username: "bob", Password: "secret"}
+	client, error := Call(context.Background(), "GET", types.URL(server.URL+"/users?page=1"), "text/plain", nil, nil, Options{Timeout: options.Timeout, Auth: auth}, noLog)
+	if error != nil {
+		t.Fatal("Expected nil, got ", error)
+	}
+	if client.Response.StatusCode != "200" || string(client.Body) != "welcome bob" {
+		t.Error("Expected 200 welcome bob, got ", client.Response.StatusCode, string(client.Body))
+	}
+	if calls != 2 {
+		t.Error("Expected 2 calls, got ", calls)
+	}
+
+	auth.Password = "wrong"
+	client, _ = Call(context.Background(), "GET", types.URL(server.URL), "text/plain", nil, nil, Options{Timeout: options.Timeout, Auth: auth}, noLog)
+	if client.Response.StatusCode != "403" {
+		t.Error("Expected 403, got ", client.Response.StatusCode)
+	}
+}
+
+func TestParseAuthParams(t *testing.T) {
+	params := parseAuthParams(`realm="a, b", qop="auth", nonce=abc, algorithm=SHA-256`)
+
+	expected := map[string]string{"realm": "a, b", "qop": "auth", "nonce": "abc", "algorithm": "SHA-256"}
+	for key, value := range expected {
+		if params[key] != value {
+			t.Error("Expected ", value, " for ", key, ", got ", params[key])
+		}
+	}
+}
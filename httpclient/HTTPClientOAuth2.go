@@ -0,0 +1,200 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryDelta is the delay before the expiry from which a token is refreshed
+const tokenExpiryDelta = 30 * time.Second
+
+// OAuth2 contains the OAuth2 profile used to fetch the access token ("client_credentials" or "password" grant)
+type OAuth2 struct {
+	GrantType    string
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       string
+	Username     string
+	Password     string
+}
+
+// Token contains an OAuth2 access token (a zero expiry means the token doesn't expire)
+type Token struct {
+	AccessToken  string
+	TokenType    string
+	RefreshToken string
+	Expiry       time.Time
+}
+
+func (t Token) valid(now time.Time) bool {
+	return t.AccessToken != "" && (t.Expiry.IsZero() || now.Add(tokenExpiryDelta).Before(t.Expiry))
+}
+
+// TokenCache caches the OAuth2 tokens by profile, it can be used from several goroutines
+type TokenCache struct {
+	mutex    sync.Mutex
+	tokens   map[OAuth2]Token
+	fetching map[OAuth2]chan struct{}
+	now      func() time.Time
+}
+
+// NewTokenCache returns an empty token cache
+func NewTokenCache() *TokenCache {
+	return &TokenCache{
+		tokens:   make(map[OAuth2]Token),
+		fetching: make(map[OAuth2]chan struct{}),
+		now:      time.Now,
+	}
+}
+
+// Token returns the token of the @oauth2 profile, it's fetched (with the TLS, proxy & timeout @options)
+// if not cached or refreshed (with the refresh token if available) if expired,
+// the concurrent calls for the same profile wait for the token being fetched instead of fetching it again
+func (c *TokenCache) Token(ctx context.Context, oauth2 OAuth2, options Options, logger func(message string, mode string)) (Token, error) {
+	for {
+		c.mutex.Lock()
+		cached, exists := c.tokens[oauth2]
+		if exists && cached.valid(c.now()) {
+			c.mutex.Unlock()
+			return cached, nil
+		}
+		if done, fetching := c.fetching[oauth2]; fetching {
+			c.mutex.Unlock()
+			select {
+			case <-done:
+				// fetched (or failed) by another call, check the cache again
+				continue
+			case <-ctx.Done():
+				return Token{}, ctx.Err()
+			}
+		}
+		done := make(chan struct{})
+		c.fetching[oauth2] = done
+		c.mutex.Unlock()
+
+		// the lock is not held during the HTTP call, the other profiles are not blocked
+		token, err := c.fetch(ctx, oauth2, cached, exists, options, logger)
+
+		c.mutex.Lock()
+		if err != nil {
+			delete(c.tokens, oauth2)
+		} else {
+			c.tokens[oauth2] = token
+		}
+		delete(c.fetching, oauth2)
+		close(done)
+		c.mutex.Unlock()
+		return token, err
+	}
+}
+
+// fetch refreshes the @cached token with its refresh token (if available) or fetches a new one
+func (c *TokenCache) fetch(ctx context.Context, oauth2 OAuth2, cached Token, exists bool, options Options, logger func(message string, mode string)) (Token, error) {
+	var token Token
+	var err error
+	if exists && cached.RefreshToken != "" {
+		logger("Refresh the OAuth2 token.", "debug")
+		token, err = fetchToken(ctx, oauth2, url.Values{"grant_type": {"refresh_token"}, "refresh_token": {cached.RefreshToken}}, options, c.now)
+		if err == nil && token.RefreshToken == "" {
+			token.RefreshToken = cached.RefreshToken
+		}
+	}
+	if !exists || cached.RefreshToken == "" || err != nil {
+		logger("Fetch the OAuth2 token.", "debug")
+		token, err = fetchToken(ctx, oauth2, oauth2.grant(), options, c.now)
+	}
+	if err != nil {
+		return Token{}, err
+	}
+	return token, nil
+}
+
+// Invalidate removes the cached token of the @oauth2 profile (ex. rejected by the server)
+func (c *TokenCache) Invalidate(oauth2 OAuth2) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.tokens, oauth2)
+}
+
+// grant returns the form values of the token request
+func (o OAuth2) grant() url.Values {
+	values := url.Values{"grant_type": {o.GrantType}}
+	if o.Scopes != "" {
+		values.Set("scope", o.Scopes)
+	}
+	if o.GrantType == "password" {
+		values.Set("username", o.Username)
+		values.Set("password", o.Password)
+	}
+	return values
+}
+
+// fetchToken posts the @values to the token endpoint, the client is authenticated with the basic scheme (RFC 6749 2.3.1)
+func fetchToken(ctx context.Context, oauth2 OAuth2, values url.Values, options Options, now func() time.Time) (Token, error) {
+	var proxy string
+	client, err := newClient(options, &proxy, func(message string, mode string) {})
+	if err != nil {
+		return Token{}, err
+	}
+
+	ctx, cancel := withTotalTimeout(ctx, options.Timeout.Total)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", oauth2.TokenURL, strings.NewReader(values.Encode()))
+	if err != nil {
+		return Token{}, errors.New("invalid OAuth2 token request: " + err.Error())
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(oauth2.ClientID), url.QueryEscape(oauth2.ClientSecret))
+
+	start := now()
+	resp, err := client.Do(req)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return Token{}, context.Canceled
+		}
+		return Token{}, errors.New("impossible to fetch the OAuth2 token: " + withTimeoutPhase(ctx, err, options.Timeout, "token").Error())
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return Token{}, errors.New("impossible to read the OAuth2 token: " + err.Error())
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message := strings.TrimSpace(string(body))
+		if len(message) > 200 {
+			message = message[:200] + "..."
+		}
+		return Token{}, errors.New("OAuth2 token request failed (" + resp.Status + "): " + message)
+	}
+
+	var data struct {
+		AccessToken  string      `json:"access_token"`
+		TokenType    string      `json:"token_type"`
+		RefreshToken string      `json:"refresh_token"`
+		ExpiresIn    json.Number `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return Token{}, errors.New("invalid OAuth2 token response: " + err.Error())
+	}
+	if data.AccessToken == "" {
+		return Token{}, errors.New("invalid OAuth2 token response: no access_token")
+	}
+
+	token := Token{AccessToken: data.AccessToken, TokenType: data.TokenType, RefreshToken: data.RefreshToken}
+	if seconds, err := strconv.ParseInt(data.ExpiresIn.String(), 10, 64); err == nil && seconds > 0 {
+		token.Expiry = start.Add(time.Duration(seconds) * time.Second)
+	}
+	return token, nil
+}
@@ -0,0 +1,157 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenCache(t *testing.T) {
+	var grants []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, secret, _ := r.BasicAuth()
+		if r.Method != "POST" || clientID != "gttp" || secret != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		r.ParseForm()
+		grant := r.PostForm.Get("grant_type")
+		grants = append(grants, grant)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch grant {
+		case "client_credentials":
+			w.Write([]byte(`{"access_token":"token-` + strconv.Itoa(len(grants)) + `","token_type":"Bearer","expires_in":60,"scope":"` + r.PostForm.Get("scope") + `"}`))
+		case "password":
+			if r.PostForm.Get("username") != "bob" || r.PostForm.Get("password") != "pwd" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"invalid_grant"}`))
+				return
+			}
+			w.Write([]byte(`{"access_token":"token-` + strconv.Itoa(len(grants)) + `","expires_in":"60","refresh_token":"refresh-1"}`))
+		case "refresh_token":
+			if r.PostForm.Get("refresh_token") != "refresh-1" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"access_token":"refreshed-` + strconv.Itoa(len(grants)) + `","expires_in":60}`))
+		}
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	cache := NewTokenCache()
+	cache.now = func() time.Time { return now }
+
+	token := func(oauth2 OAuth2) (Token, error) {
+		return cache.Token(context.Background(), oauth2, options, noLog)
+	}
+
+	// client credentials grant, the token is cached until its expiry
+	clientCredentials := OAuth2{GrantType: "client_credentials", TokenURL: server.URL, ClientID: "gttp", ClientSecret: "s3cret", Scopes: "read write"}
+	for _, expected := range []string{"token-1", "token-1"} {
+		if actual, error := token(clientCredentials); error != nil || actual.AccessToken != expected {
+			t.Error("Expected ", expected, ", got ", actual, error)
+		}
+	}
+	now = now.Add(45 * time.Second)
+	if actual, error := token(clientCredentials); error != nil || actual.AccessToken != "token-2" {
+		t.Error("Expected token-2, got ", actual, error)
+	}
+
+	// password grant, the token is refreshed with the refresh token
+	password := OAuth2{GrantType: "password", TokenURL: server.URL, ClientID: "gttp", ClientSecret: "s3cret", Username: "bob", Password: "pwd"}
+	if actual, error := token(password); error != nil || actual.AccessToken != "token-3" {
+		t.Error("Expected token-3, got ", actual, error)
+	}
+	now = now.Add(time.Hour)
+	actual, error := token(password)
+	if error != nil || actual.AccessToken != "refreshed-4" || actual.RefreshToken != "refresh-1" {
+		t.Error("Expected refreshed-4, got ", actual, error)
+	}
+
+	cache.Invalidate(password)
+	if actual, error := token(password); error != nil || actual.AccessToken != "token-5" {
+		t.Error("Expected token-5, got ", actual, error)
+	}
+
+	expected := []string{"client_credentials", "client_credentials", "password", "refresh_token", "password"}
+	if len(grants) != len(expected) {
+		t.Fatal("Expected ", expected, ", got ", grants)
+	}
+	for index, grant := range expected {
+		if grants[index] != grant {
+			t.Error("Expected ", expected, ", got ", grants)
+		}
+	}
+
+	// invalid client
+	clientCredentials.ClientSecret = "wrong"
+	if _, error := token(clientCredentials); error == nil || error.Error() != `OAuth2 token request failed (401 Unauthorized): {"error":"invalid_client"}` {
+		t.Error("Expected invalid_client error, got ", error)
+	}
+}
+
+func TestTokenCacheConcurrent(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.PostForm.Get("scope") == "slow" {
+			atomic.AddInt32(&calls, 1)
+			<-release
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"token-` + r.PostForm.Get("scope") + `","expires_in":60}`))
+	}))
+	defer server.Close()
+
+	cache := NewTokenCache()
+	slow := OAuth2{GrantType: "client_credentials", TokenURL: server.URL, Scopes: "slow"}
+	fast := OAuth2{GrantType: "client_credentials", TokenURL: server.URL, Scopes: "fast"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if actual, error := cache.Token(context.Background(), slow, options, noLog); error != nil || actual.AccessToken != "token-slow" {
+				t.Error("Expected token-slow, got ", actual, error)
+			}
+		}()
+	}
+
+	// another profile is not blocked by the token being fetched
+	if actual, error := cache.Token(context.Background(), fast, options, noLog); error != nil || actual.AccessToken != "token-fast" {
+		t.Error("Expected token-fast, got ", actual, error)
+	}
+
+	close(release)
+	wg.Wait()
+	if calls := atomic.LoadInt32(&calls); calls != 1 {
+		t.Error("Expected 1 token request, got ", calls)
+	}
+}
+
+func TestTokenValid(t *testing.T) {
+	now := time.Now()
+
+	values := map[bool]Token{
+		true:  {AccessToken: "abc"},
+		false: {AccessToken: "abc", Expiry: now.Add(10 * time.Second)},
+	}
+	for expected, token := range values {
+		if actual := token.valid(now); actual != expected {
+			t.Error("Expected ", expected, " for ", token, ", got ", actual)
+		}
+	}
+	if (Token{}).valid(now) {
+		t.Error("Expected false, got true")
+	}
+}
@@ -0,0 +1,94 @@
+package httpclient
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// proxyDirect is the proxy value to connect directly
+const proxyDirect = "direct"
+
+// Proxy contains the proxy settings of the request, an empty URL means the
+// HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables
+type Proxy struct {
+	URL      string
+	Username string
+	Password string
+	NoProxy  string
+}
+
+// proxyFunc builds the proxy function of the transport, the used proxy (or "direct") is recorded in @used
+func (p Proxy) proxyFunc(used *string) (func(*http.Request) (*url.URL, error), error) {
+	record := func(proxy *url.URL) {
+		if proxy == nil {
+			*used = proxyDirect
+		} else {
+			*used = proxy.Redacted()
+		}
+	}
+
+	if p.URL == "" {
+		return func(request *http.Request) (*url.URL, error) {
+			proxy, err := http.ProxyFromEnvironment(request)
+			record(proxy)
+			return proxy, err
+		}, nil
+	}
+
+	if p.URL == proxyDirect {
+		return func(request *http.Request) (*url.URL, error) {
+			record(nil)
+			return nil, nil
+		}, nil
+	}
+
+	proxy, err := url.Parse(p.URL)
+	if err != nil || proxy.Host == "" {
+		return nil, errors.New("invalid proxy URL '" + p.URL + "'")
+	}
+	if p.Username != "" {
+		proxy.User = url.UserPassword(p.Username, p.Password)
+	}
+
+	return func(request *http.Request) (*url.URL, error) {
+		if p.bypass(request.URL.Hostname()) {
+			record(nil)
+			return nil, nil
+		}
+		record(proxy)
+		return proxy, nil
+	}, nil
+}
+
+// bypass returns true if the @host matches the no-proxy list
+// ("*", "example.com" (and its sub-domains), ".example.com", "10.0.0.0/8" or an IP)
+func (p Proxy) bypass(host string) bool {
+	host = strings.ToLower(host)
+	ip := net.ParseIP(host)
+
+	for _, value := range strings.Split(p.NoProxy, ",") {
+		value = strings.ToLower(strings.TrimSpace(value))
+		switch {
+		case value == "":
+			continue
+		case value == "*":
+			return true
+		case strings.Contains(value, "/"):
+			if _, network, err := net.ParseCIDR(value); err == nil && ip != nil && network.Contains(ip) {
+				return true
+			}
+		default:
+			if h, _, err := net.SplitHostPort(value); err == nil {
+				value = h
+			}
+			domain := strings.TrimPrefix(value, ".")
+			if host == domain || strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+		}
+	}
+	return false
+}
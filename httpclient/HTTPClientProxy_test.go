@@ -0,0 +1,72 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joakim-ribier/gttp/models/types"
+)
+
+func TestCallProxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("proxied " + r.URL.String() + " " + r.Header.Get("Proxy-Authorization")))
+	}))
+	defer proxy.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("direct"))
+	}))
+	defer server.Close()
+
+	call := func(url string, settings Proxy) *HTTPClient {
+		client, error := Call(context.Background(), "GET", types.URL(url), "text/plain", nil, nil, Options{Timeout: options.Timeout, Proxy: settings}, noLog)
+		if error != nil {
+			t.Fatal("Expected nil, got ", error)
+		}
+		return client
+	}
+
+	client := call("http://api.internal/users", Proxy{URL: proxy.URL, Username: "bob", Password: "secret"})
+	if string(client.Body) != "proxied http://api.internal/users Basic Ym9iOnNlY3JldA==" {
+		t.Error("Expected proxied request, got ", string(client.Body))
+	}
+	if client.Proxy != "http://bob:xxxxx@"+proxy.Listener.Addr().String() {
+		t.Error("Expected redacted proxy URL, got ", client.Proxy)
+	}
+
+	client = call(server.URL, Proxy{URL: proxy.URL, NoProxy: "example.com, 127.0.0.0/8"})
+	if string(client.Body) != "direct" || client.Proxy != "direct" {
+		t.Error("Expected direct request, got ", string(client.Body), client.Proxy)
+	}
+
+	client = call(server.URL, Proxy{URL: "direct"})
+	if string(client.Body) != "direct" {
+		t.Error("Expected direct request, got ", string(client.Body))
+	}
+}
+
+func TestProxyBypass(t *testing.T) {
+	proxy := Proxy{NoProxy: "localhost, .corp.net, example.com:8080, 10.0.0.0/8"}
+
+	values := map[string]bool{
+		"localhost":       true,
+		"api.corp.net":    true,
+		"corp.net":        true,
+		"example.com":     true,
+		"www.example.com": true,
+		"10.1.2.3":        true,
+		"11.1.2.3":        false,
+		"notexample.com":  false,
+		"github.com":      false,
+	}
+	for host, expected := range values {
+		if actual := proxy.bypass(host); actual != expected {
+			t.Error("Expected ", expected, " for ", host, ", got ", actual)
+		}
+	}
+	if !(Proxy{NoProxy: "*"}).bypass("github.com") {
+		t.Error("Expected true, got false")
+	}
+}
@@ -2,55 +2,270 @@ package httpclient
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"net/http/httptrace"
+	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/joakim-ribier/gttp/models/types"
 )
 
-// Call http method
-func Call(method types.Method, url types.URL, contentType string, data []byte, headers map[string]string, logger func(message string, mode string)) (*HTTPClient, error) {
-	return getJSON(method, url, contentType, data, headers, logger)
+// Timeout contains the connection and the total (connection + response reading) timeouts
+type Timeout struct {
+	Connect time.Duration
+	Total   time.Duration
 }
 
-func getJSON(method types.Method, url types.URL, contentType string, data []byte, headers map[string]string, logger func(message string, mode string)) (*HTTPClient, error) {
+// Options contains the settings of the request execution
+type Options struct {
+	Timeout Timeout
+	TLS     TLS
+	Proxy   Proxy
+	Auth    Auth
+}
+
+// TimeoutError is returned when a request exceeds one of its timeouts
+type TimeoutError struct {
+	Phase   string
+	Timeout time.Duration
+	Err     error
+}
+
+func (e *TimeoutError) Error() string {
+	return "timeout (" + e.Timeout.String() + ") exceeded during the '" + e.Phase + "' phase: " + e.Err.Error()
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// Call http method, the request is aborted as soon as the @ctx is cancelled
+func Call(ctx context.Context, method types.Method, url types.URL, contentType string, data []byte, headers map[string]string, options Options, logger func(message string, mode string)) (*HTTPClient, error) {
+	return getJSON(ctx, method, url, contentType, data, headers, options, logger)
+}
+
+func getJSON(ctx context.Context, method types.Method, url types.URL, contentType string, data []byte, headers map[string]string, options Options, logger func(message string, mode string)) (*HTTPClient, error) {
 	logger(method.String()+" "+url.String(), "debug")
 
-	timeout := time.Duration(5 * time.Second)
-	client := &http.Client{
-		Timeout: timeout,
+	timeout := options.Timeout
+	var proxy string
+	client, err := newClient(options, &proxy, logger)
+	if err != nil {
+		return nil, err
 	}
 
-	req, err := http.NewRequest(method.String(), url.String(), bytes.NewBuffer(data))
-	req.Header.Set("Content-Type", contentType)
+	ctx, cancel := withTotalTimeout(ctx, timeout.Total)
+	defer cancel()
+
+	newRequest := func(authorization string) (*http.Request, *timingTrace, error) {
+		req, err := http.NewRequestWithContext(ctx, method.String(), url.String(), bytes.NewBuffer(data))
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Header.Set("Content-Type", contentType)
+
+		// Set HTTP header values
+		for key, value := range headers {
+			if !(strings.HasPrefix(key, "{") && strings.HasSuffix(key, "}")) {
+				req.Header.Set(key, value)
+			}
+		}
+
+		options.Auth.apply(req)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+
+		timing := newTimingTrace()
+		return req.WithContext(httptrace.WithClientTrace(req.Context(), timing.trace())), timing, nil
+	}
 
-	// Set HTTP header values
-	for key, value := range headers {
-		if !(strings.HasPrefix(key, "{") && strings.HasSuffix(key, "}")) {
-			req.Header.Set(key, value)
+	do := func(req *http.Request) (*http.Response, error) {
+		resp, err := client.Do(req)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil, context.Canceled
+			}
+			logger("Impossible to execute the query.", "error")
+			return nil, withTimeoutPhase(ctx, err, timeout, "response")
 		}
+		return resp, nil
 	}
 
+	req, timing, err := newRequest("")
 	if err != nil {
 		logger("Impossible to build the query.", "error")
 		return nil, err
 	}
 
-	resp, err := client.Do(req)
+	resp, err := do(req)
 	if err != nil {
-		logger("Impossible to execute the query.", "error")
 		return nil, err
 	}
+
+	// Digest authentication, the request is sent again with the response of the server challenge
+	if options.Auth.Scheme == AuthDigest && resp.StatusCode == http.StatusUnauthorized {
+		if challenge := digestChallenge(resp.Header); challenge != "" {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+
+			authorization, err := options.Auth.digestAuthorization(req.Method, req.URL.RequestURI(), challenge)
+			if err != nil {
+				logger("Impossible to answer the digest challenge.", "error")
+				return nil, err
+			}
+			if req, timing, err = newRequest(authorization); err != nil {
+				logger("Impossible to build the query.", "error")
+				return nil, err
+			}
+			if resp, err = do(req); err != nil {
+				return nil, err
+			}
+		}
+	}
 	defer resp.Body.Close()
 
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil, context.Canceled
+		}
 		logger("Impossible to read the response body.", "error")
-		return nil, err
+		return nil, withTimeoutPhase(ctx, err, timeout, "body reading")
 	}
 
 	httpClient := NewHTTPClient(resp, body).withHeaderData(logger)
+	httpClient.Timing = timing.done()
+	httpClient.Proxy = proxy
 	return httpClient, nil
 }
+
+// newClient builds the http client with the connect timeout, TLS & proxy @options (the used proxy is recorded in @proxy),
+// the total timeout is the deadline of the request context (see withTotalTimeout)
+func newClient(options Options, proxy *string, logger func(message string, mode string)) (*http.Client, error) {
+	tlsConfig, err := options.TLS.config()
+	if err != nil {
+		logger("Impossible to configure TLS.", "error")
+		return nil, err
+	}
+	proxyFunc, err := options.Proxy.proxyFunc(proxy)
+	if err != nil {
+		logger("Impossible to configure the proxy.", "error")
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   options.Timeout.Connect,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.TLSHandshakeTimeout = options.Timeout.Connect
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	transport.Proxy = proxyFunc
+
+	return &http.Client{
+		Transport: transport,
+	}, nil
+}
+
+// timingTrace records the time of each phase of the request (the hooks could be called from several goroutines)
+type timingTrace struct {
+	mutex                                                                                      sync.Mutex
+	start, dnsStart, dnsDone, connectStart, connectDone, tlsStart, tlsDone, gotConn, firstByte time.Time
+	reused                                                                                     bool
+}
+
+func newTimingTrace() *timingTrace {
+	return &timingTrace{start: time.Now()}
+}
+
+func (t *timingTrace) now(value *time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	*value = time.Now()
+}
+
+func (t *timingTrace) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart:          func(httptrace.DNSStartInfo) { t.now(&t.dnsStart) },
+		DNSDone:           func(httptrace.DNSDoneInfo) { t.now(&t.dnsDone) },
+		ConnectStart:      func(string, string) { t.now(&t.connectStart) },
+		ConnectDone:       func(string, string, error) { t.now(&t.connectDone) },
+		TLSHandshakeStart: func() { t.now(&t.tlsStart) },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { t.now(&t.tlsDone) },
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.now(&t.gotConn)
+			t.mutex.Lock()
+			t.reused = info.Reused
+			t.mutex.Unlock()
+		},
+		GotFirstResponseByte: func() { t.now(&t.firstByte) },
+	}
+}
+
+// done returns the timing of the phases once the body is read (a phase which didn't happen is zero)
+func (t *timingTrace) done() Timing {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	end := time.Now()
+	between := func(start time.Time, end time.Time) time.Duration {
+		if start.IsZero() || end.IsZero() || end.Before(start) {
+			return 0
+		}
+		return end.Sub(start)
+	}
+	return Timing{
+		DNSLookup:        between(t.dnsStart, t.dnsDone),
+		TCPConnection:    between(t.connectStart, t.connectDone),
+		TLSHandshake:     between(t.tlsStart, t.tlsDone),
+		TimeToFirstByte:  between(t.gotConn, t.firstByte),
+		ContentTransfer:  between(t.firstByte, end),
+		Total:            between(t.start, end),
+		ConnectionReused: t.reused,
+	}
+}
+
+// withTotalTimeout returns the @ctx with the deadline of the total @timeout (if defined)
+func withTotalTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// withTimeoutPhase wraps @err in a TimeoutError (with the phase which timed out) if it's a timeout error,
+// the total deadline of the @ctx is checked first because it can expire during any phase
+func withTimeoutPhase(ctx context.Context, err error, timeout Timeout, phase string) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return &TimeoutError{Phase: phase, Timeout: timeout.Total, Err: err}
+	}
+
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		return err
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return &TimeoutError{Phase: "connect", Timeout: timeout.Connect, Err: err}
+	}
+	if strings.Contains(err.Error(), "TLS handshake timeout") {
+		return &TimeoutError{Phase: "TLS handshake", Timeout: timeout.Connect, Err: err}
+	}
+	return err
+}
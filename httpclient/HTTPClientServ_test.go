@@ -0,0 +1,153 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joakim-ribier/gttp/models/types"
+)
+
+var (
+	noLog   = func(message string, mode string) {}
+	options = Options{Timeout: Timeout{Connect: time.Second, Total: 5 * time.Second}}
+)
+
+func TestCall(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Method + " " + r.Header.Get("X-Value")))
+	}))
+	defer server.Close()
+
+	client, error := Call(context.Background(), "PATCH", types.URL(server.URL), "text/plain", nil, map[string]string{"X-Value": "value"}, options, noLog)
+
+	if error != nil {
+		t.Fatal("Expected nil, got ", error)
+	}
+	if string(client.Body) != "PATCH value" {
+		t.Error("Expected 'PATCH value', got ", string(client.Body))
+	}
+}
+
+func TestCallResponseHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Set-Cookie", "a=1")
+		w.Header().Add("Set-Cookie", "b=2")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("X-Request-Id", "42")
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	client, error := Call(context.Background(), "GET", types.URL(server.URL), "text/plain", nil, nil, options, noLog)
+	if error != nil {
+		t.Fatal("Expected nil, got ", error)
+	}
+
+	if cookies := client.HeadersResponse.Values("Set-Cookie"); len(cookies) != 2 || cookies[0] != "a=1" || cookies[1] != "b=2" {
+		t.Error("Expected [a=1 b=2], got ", cookies)
+	}
+	for _, key := range []string{"Cache-Control", "Access-Control-Allow-Origin", "X-Request-Id", "Content-Length", "Date"} {
+		if client.HeadersResponse.Get(key) == "" {
+			t.Error("Expected header ", key, ", got ", client.HeadersResponse)
+		}
+	}
+
+	keys := client.SortedHeadersResponse()
+	if keys[0] != "Access-Control-Allow-Origin" || keys[len(keys)-1] != "X-Request-Id" {
+		t.Error("Expected sorted headers, got ", keys)
+	}
+}
+
+func TestCallCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, error := Call(ctx, "GET", types.URL(server.URL), "text/plain", nil, nil, options, noLog)
+
+	if !errors.Is(error, context.Canceled) {
+		t.Error("Expected context.Canceled, got ", error)
+	}
+}
+
+func TestCallTotalTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	_, error := Call(context.Background(), "GET", types.URL(server.URL), "text/plain", nil, nil, Options{Timeout: Timeout{Connect: time.Second, Total: 50 * time.Millisecond}}, noLog)
+
+	var timeoutError *TimeoutError
+	if !errors.As(error, &timeoutError) || timeoutError.Phase != "response" {
+		t.Error("Expected 'response' TimeoutError, got ", error)
+	}
+}
+
+func TestCallTotalTimeoutDuringHandshake(t *testing.T) {
+	// the server accepts the connections but never answers the TLS handshake
+	listener, error := net.Listen("tcp", "127.0.0.1:0")
+	if error != nil {
+		t.Fatal("Expected nil, got ", error)
+	}
+	defer listener.Close()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			conn, error := listener.Accept()
+			if error != nil {
+				return
+			}
+			// each connection is kept open (without answer) until the end of the test
+			go func(conn net.Conn) {
+				<-done
+				conn.Close()
+			}(conn)
+		}
+	}()
+
+	_, error = Call(context.Background(), "GET", types.URL("https://"+listener.Addr().String()), "text/plain", nil, nil, Options{Timeout: Timeout{Connect: 5 * time.Second, Total: 50 * time.Millisecond}}, noLog)
+
+	var timeoutError *TimeoutError
+	if !errors.As(error, &timeoutError) || timeoutError.Timeout != 50*time.Millisecond {
+		t.Error("Expected total TimeoutError, got ", error)
+	}
+}
+
+func TestCallTiming(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client, error := Call(context.Background(), "GET", types.URL(server.URL), "text/plain", nil, nil, options, noLog)
+	if error != nil {
+		t.Fatal("Expected nil, got ", error)
+	}
+
+	timing := client.Timing
+	if timing.TimeToFirstByte < 50*time.Millisecond {
+		t.Error("Expected TTFB >= 50ms, got ", timing.TimeToFirstByte)
+	}
+	if timing.TCPConnection <= 0 || timing.TLSHandshake != 0 {
+		t.Error("Expected TCP connection without TLS handshake, got ", timing)
+	}
+	if timing.Total < timing.TCPConnection+timing.TimeToFirstByte+timing.ContentTransfer {
+		t.Error("Expected total >= sum of the phases, got ", timing)
+	}
+}
@@ -0,0 +1,116 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TLS contains the TLS settings of the request (PEM files), an empty value means "default"
+type TLS struct {
+	CAFile             string
+	CertFile           string
+	KeyFile            string
+	MinVersion         string
+	InsecureSkipVerify bool
+}
+
+// TLSInfo contains the negotiated TLS connection of the response
+type TLSInfo struct {
+	Version      string
+	CipherSuite  string
+	Protocol     string
+	Certificates []CertificateInfo
+}
+
+// CertificateInfo contains the main information of a peer certificate
+type CertificateInfo struct {
+	Subject  string
+	Issuer   string
+	NotAfter time.Time
+	DNSNames []string
+}
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// config builds the TLS configuration (nil if it's the default one)
+func (t TLS) config() (*tls.Config, error) {
+	if t == (TLS{}) {
+		return nil, nil
+	}
+
+	config := &tls.Config{InsecureSkipVerify: t.InsecureSkipVerify}
+
+	if t.MinVersion != "" {
+		version, exists := tlsVersions[t.MinVersion]
+		if !exists {
+			return nil, errors.New("invalid TLS version '" + t.MinVersion + "'")
+		}
+		config.MinVersion = version
+	}
+
+	if t.CAFile != "" {
+		data, err := ioutil.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, errors.New("impossible to read the CA file: " + err.Error())
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, errors.New("no PEM certificate in the CA file '" + t.CAFile + "'")
+		}
+		config.RootCAs = pool
+	}
+
+	if t.CertFile != "" || t.KeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, errors.New("impossible to load the client certificate: " + err.Error())
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config, nil
+}
+
+// newTLSInfo returns the information of the TLS @state (nil if it's not a TLS connection)
+func newTLSInfo(state *tls.ConnectionState) *TLSInfo {
+	if state == nil {
+		return nil
+	}
+
+	info := &TLSInfo{
+		Version:     tlsVersionName(state.Version),
+		CipherSuite: tls.CipherSuiteName(state.CipherSuite),
+		Protocol:    state.NegotiatedProtocol,
+	}
+	for _, certificate := range state.PeerCertificates {
+		info.Certificates = append(info.Certificates, CertificateInfo{
+			Subject:  certificate.Subject.String(),
+			Issuer:   certificate.Issuer.String(),
+			NotAfter: certificate.NotAfter,
+			DNSNames: certificate.DNSNames,
+		})
+	}
+	return info
+}
+
+func tlsVersionName(version uint16) string {
+	for name, value := range tlsVersions {
+		if value == version {
+			return "TLS " + name
+		}
+	}
+	return "0x" + strings.ToUpper(strconv.FormatUint(uint64(version), 16))
+}
@@ -0,0 +1,124 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/joakim-ribier/gttp/models/types"
+)
+
+// writePEM writes the PEM @blocks in a temporary file and returns its name
+func writePEM(t *testing.T, name string, blocks ...*pem.Block) string {
+	filename := filepath.Join(t.TempDir(), name)
+	file, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	for _, block := range blocks {
+		pem.Encode(file, block)
+	}
+	return filename
+}
+
+// newTLSServer starts a TLS server which returns the common name of the client certificate (if any)
+func newTLSServer(t *testing.T, clientAuth tls.ClientAuthType) (*httptest.Server, string) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) > 0 {
+			w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+		}
+	}))
+	server.TLS = &tls.Config{ClientAuth: clientAuth}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	ca := writePEM(t, "ca.pem", &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	return server, ca
+}
+
+func TestCallTLS(t *testing.T) {
+	server, ca := newTLSServer(t, tls.NoClientCert)
+
+	call := func(tlsOptions TLS) (*HTTPClient, error) {
+		return Call(context.Background(), "GET", types.URL(server.URL), "text/plain", nil, nil, Options{Timeout: options.Timeout, TLS: tlsOptions}, noLog)
+	}
+
+	if _, error := call(TLS{}); error == nil {
+		t.Error("Expected unknown authority error, got nil")
+	}
+
+	client, error := call(TLS{CAFile: ca})
+	if error != nil {
+		t.Fatal("Expected nil, got ", error)
+	}
+	if client.TLS == nil || client.TLS.Version == "" || len(client.TLS.Certificates) == 0 {
+		t.Error("Expected TLS info, got ", client.TLS)
+	}
+
+	if _, error := call(TLS{InsecureSkipVerify: true}); error != nil {
+		t.Error("Expected nil, got ", error)
+	}
+	if _, error := call(TLS{CAFile: "unknown.pem"}); error == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestCallTLSMinVersion(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	_, error := Call(context.Background(), "GET", types.URL(server.URL), "text/plain", nil, nil, Options{Timeout: options.Timeout, TLS: TLS{InsecureSkipVerify: true, MinVersion: "1.3"}}, noLog)
+	if error == nil {
+		t.Error("Expected protocol version error, got nil")
+	}
+
+	client, error := Call(context.Background(), "GET", types.URL(server.URL), "text/plain", nil, nil, Options{Timeout: options.Timeout, TLS: TLS{InsecureSkipVerify: true, MinVersion: "1.2"}}, noLog)
+	if error != nil || client.TLS.Version != "TLS 1.2" {
+		t.Error("Expected TLS 1.2, got ", client, error)
+	}
+}
+
+func TestCallClientCertificate(t *testing.T) {
+	server, ca := newTLSServer(t, tls.RequireAnyClientCert)
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "gttp-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, _ := x509.MarshalECPrivateKey(key)
+
+	cert := writePEM(t, "client.crt", &pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyFile := writePEM(t, "client.key", &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+
+	if _, error := Call(context.Background(), "GET", types.URL(server.URL), "text/plain", nil, nil, Options{Timeout: options.Timeout, TLS: TLS{CAFile: ca}}, noLog); error == nil {
+		t.Error("Expected client certificate error, got nil")
+	}
+
+	client, error := Call(context.Background(), "GET", types.URL(server.URL), "text/plain", nil, nil, Options{Timeout: options.Timeout, TLS: TLS{CAFile: ca, CertFile: cert, KeyFile: keyFile}}, noLog)
+	if error != nil || string(client.Body) != "gttp-client" {
+		t.Error("Expected 'gttp-client', got ", client, error)
+	}
+}
@@ -2,10 +2,12 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	myapp "github.com/joakim-ribier/gttp/app"
+	"github.com/joakim-ribier/gttp/cli"
 	"github.com/joakim-ribier/gttp/utils"
 	"github.com/rivo/tview"
 )
@@ -24,6 +26,11 @@ const logo = `
 `
 
 func main() {
+	// Headless mode (no terminal UI)
+	if len(os.Args) > 1 && os.Args[1] == "run" {
+		os.Exit(cli.Run(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	theme()
 
 	app := tview.NewApplication()
@@ -9,6 +9,7 @@ type AppCtx struct {
 	AddListenerMRD     map[string]func(data MakeRequestData)
 	AddListenerConfig  map[string]func(data Config)
 	AddContextListener map[string]func(data Context)
+	AddListenerHistory map[string]func(data History)
 
 	GetMDR    func() MakeRequestData
 	UpdateMDR func(data MakeRequestData)
@@ -44,6 +45,7 @@ func NewAppCtx(
 		AddListenerMRD:     make(map[string]func(data MakeRequestData)),
 		AddListenerConfig:  make(map[string]func(data Config)),
 		AddContextListener: make(map[string]func(data Context)),
+		AddListenerHistory: make(map[string]func(data History)),
 		UpdateMDR:          upMDR,
 		GetMDR:             getMDR,
 		GetConfig:          getConfig,
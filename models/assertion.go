@@ -0,0 +1,222 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joakim-ribier/gttp/core"
+)
+
+// Represents the assertion types
+const (
+	AssertStatusEquals   = "Status equals"
+	AssertStatusInRange  = "Status in range"
+	AssertHeaderExists   = "Header exists"
+	AssertHeaderMatches  = "Header matches"
+	AssertBodyContains   = "Body contains"
+	AssertJSONPathEquals = "JSONPath equals"
+	AssertDurationBelow  = "Duration below"
+)
+
+// AssertionTypes contains all the assertion types
+var AssertionTypes = core.StringSlice{
+	AssertStatusEquals,
+	AssertStatusInRange,
+	AssertHeaderExists,
+	AssertHeaderMatches,
+	AssertBodyContains,
+	AssertJSONPathEquals,
+	AssertDurationBelow,
+}
+
+// Assertion represents what a good response looks like
+// (@Target is the header name or the JSONPath expression, @Value the expected value)
+type Assertion struct {
+	Type   string
+	Target string
+	Value  string
+}
+
+// AssertionResult represents the result of an evaluated assertion
+type AssertionResult struct {
+	Assertion Assertion
+	Passed    bool
+	Message   string
+}
+
+// NewAssertion creates a new Assertion struct
+func NewAssertion(assertionType string, target string, value string) Assertion {
+	return Assertion{
+		Type:   assertionType,
+		Target: target,
+		Value:  value,
+	}
+}
+
+// String returns the assertion as a readable string
+func (a Assertion) String() string {
+	switch a.Type {
+	case AssertHeaderExists:
+		return "Header '" + a.Target + "' exists"
+	case AssertHeaderMatches:
+		return "Header '" + a.Target + "' matches '" + a.Value + "'"
+	case AssertJSONPathEquals:
+		return a.Target + " equals '" + a.Value + "'"
+	case AssertBodyContains:
+		return a.Type + " '" + a.Value + "'"
+	default:
+		return a.Type + " " + a.Value
+	}
+}
+
+// ReplaceContext replaces the context {variable} of the target and the value by the context values
+func (a Assertion) ReplaceContext(contextValues map[string]string) Assertion {
+	replace := func(value string) string {
+		return core.ReplaceContext(value, contextValues)
+	}
+	return NewAssertion(a.Type, replace(a.Target), replace(a.Value))
+}
+
+// Validate checks that the assertion is well defined
+func (a Assertion) Validate() error {
+	switch a.Type {
+	case AssertStatusEquals:
+		if _, error := strconv.Atoi(a.Value); error != nil {
+			return errors.New("invalid status '" + a.Value + "'")
+		}
+	case AssertStatusInRange:
+		if _, _, error := parseStatusRange(a.Value); error != nil {
+			return error
+		}
+	case AssertHeaderExists:
+		if a.Target == "" {
+			return errors.New("empty header name")
+		}
+	case AssertHeaderMatches:
+		if a.Target == "" {
+			return errors.New("empty header name")
+		}
+		if _, error := regexp.Compile(a.Value); error != nil {
+			return errors.New("invalid regex '" + a.Value + "'")
+		}
+	case AssertBodyContains:
+		if a.Value == "" {
+			return errors.New("empty text")
+		}
+	case AssertJSONPathEquals:
+		if a.Target == "" {
+			return errors.New("empty JSONPath")
+		}
+	case AssertDurationBelow:
+		if _, error := time.ParseDuration(a.Value); error != nil {
+			return errors.New("invalid duration '" + a.Value + "' (ex. 500ms, 2s)")
+		}
+	default:
+		return errors.New("unknown assertion type '" + a.Type + "'")
+	}
+	return nil
+}
+
+// Evaluate evaluates the assertion on the @response
+func (a Assertion) Evaluate(response ResponseData) AssertionResult {
+	result := func(passed bool, message string) AssertionResult {
+		return AssertionResult{Assertion: a, Passed: passed, Message: message}
+	}
+
+	if error := a.Validate(); error != nil {
+		return result(false, error.Error())
+	}
+
+	switch a.Type {
+	case AssertStatusEquals:
+		expected, _ := strconv.Atoi(a.Value)
+		return result(response.StatusCode == expected, "got "+strconv.Itoa(response.StatusCode))
+	case AssertStatusInRange:
+		min, max, _ := parseStatusRange(a.Value)
+		return result(response.StatusCode >= min && response.StatusCode <= max, "got "+strconv.Itoa(response.StatusCode))
+	case AssertHeaderExists:
+		if values := response.Headers.Values(a.Target); len(values) > 0 {
+			return result(true, "got '"+strings.Join(values, ", ")+"'")
+		}
+		return result(false, "header not found")
+	case AssertHeaderMatches:
+		values := response.Headers.Values(a.Target)
+		if len(values) == 0 {
+			return result(false, "header not found")
+		}
+		regex := regexp.MustCompile(a.Value)
+		for _, value := range values {
+			if regex.MatchString(value) {
+				return result(true, "got '"+value+"'")
+			}
+		}
+		return result(false, "got '"+strings.Join(values, ", ")+"'")
+	case AssertBodyContains:
+		if strings.Contains(string(response.Body), a.Value) {
+			return result(true, "")
+		}
+		return result(false, "text not found")
+	case AssertJSONPathEquals:
+		value, error := core.JSONPath(response.Body, a.Target)
+		if error != nil {
+			return result(false, error.Error())
+		}
+		actual := core.JSONValueToString(value)
+		return result(actual == a.Value, "got '"+actual+"'")
+	case AssertDurationBelow:
+		expected, _ := time.ParseDuration(a.Value)
+		return result(response.Duration < expected, "got "+response.Duration.Round(time.Millisecond).String())
+	}
+	return result(false, "")
+}
+
+// EvaluateAssertions evaluates all the @assertions on the @response
+func EvaluateAssertions(assertions []Assertion, response ResponseData) []AssertionResult {
+	var results []AssertionResult
+	for _, assertion := range assertions {
+		results = append(results, assertion.Evaluate(response))
+	}
+	return results
+}
+
+// FailedAssertions returns the number of failed assertions
+func FailedAssertions(results []AssertionResult) int {
+	failed := 0
+	for _, result := range results {
+		if !result.Passed {
+			failed++
+		}
+	}
+	return failed
+}
+
+// parseStatusRange parses a status range "200-299" (or "2xx")
+func parseStatusRange(value string) (int, int, error) {
+	invalid := errors.New("invalid status range '" + value + "' (ex. 200-299 or 2xx)")
+
+	value = strings.TrimSpace(value)
+	if len(value) == 3 && strings.HasSuffix(strings.ToLower(value), "xx") {
+		class, error := strconv.Atoi(value[:1])
+		if error != nil {
+			return 0, 0, invalid
+		}
+		return class * 100, class*100 + 99, nil
+	}
+
+	bounds := strings.Split(value, "-")
+	if len(bounds) != 2 {
+		return 0, 0, invalid
+	}
+	min, error := strconv.Atoi(strings.TrimSpace(bounds[0]))
+	if error != nil {
+		return 0, 0, invalid
+	}
+	max, error := strconv.Atoi(strings.TrimSpace(bounds[1]))
+	if error != nil || max < min {
+		return 0, 0, invalid
+	}
+	return min, max, nil
+}
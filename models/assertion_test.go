@@ -0,0 +1,68 @@
+package models
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAssertionEvaluate(t *testing.T) {
+	response := ResponseData{
+		StatusCode: 201,
+		Headers:    http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+		Body:       []byte(`{"id": 42, "user": {"name": "gttp"}}`),
+		Duration:   120 * time.Millisecond,
+	}
+
+	values := map[Assertion]bool{
+		NewAssertion(AssertStatusEquals, "", "201"):                            true,
+		NewAssertion(AssertStatusEquals, "", "200"):                            false,
+		NewAssertion(AssertStatusInRange, "", "200-299"):                       true,
+		NewAssertion(AssertStatusInRange, "", "2xx"):                           true,
+		NewAssertion(AssertStatusInRange, "", "4xx"):                           false,
+		NewAssertion(AssertHeaderExists, "content-type", ""):                   true,
+		NewAssertion(AssertHeaderExists, "X-Request-Id", ""):                   false,
+		NewAssertion(AssertHeaderMatches, "Content-Type", "^application/json"): true,
+		NewAssertion(AssertHeaderMatches, "Content-Type", "xml"):               false,
+		NewAssertion(AssertBodyContains, "", `"gttp"`):                         true,
+		NewAssertion(AssertBodyContains, "", "unknown"):                        false,
+		NewAssertion(AssertJSONPathEquals, "$.user.name", "gttp"):              true,
+		NewAssertion(AssertJSONPathEquals, "$.id", "42"):                       true,
+		NewAssertion(AssertJSONPathEquals, "$.unknown", "42"):                  false,
+		NewAssertion(AssertDurationBelow, "", "500ms"):                         true,
+		NewAssertion(AssertDurationBelow, "", "100ms"):                         false,
+	}
+	for assertion, expected := range values {
+		if actual := assertion.Evaluate(response); actual.Passed != expected {
+			t.Error("Expected ", expected, " for '", assertion.String(), "', got ", actual.Passed, " (", actual.Message, ")")
+		}
+	}
+}
+
+func TestAssertionValidate(t *testing.T) {
+	invalids := []Assertion{
+		NewAssertion(AssertStatusEquals, "", "OK"),
+		NewAssertion(AssertStatusInRange, "", "299-200"),
+		NewAssertion(AssertHeaderExists, "", ""),
+		NewAssertion(AssertHeaderMatches, "Content-Type", "("),
+		NewAssertion(AssertJSONPathEquals, "", "1"),
+		NewAssertion(AssertDurationBelow, "", "fast"),
+		NewAssertion("Unknown", "", ""),
+	}
+	for _, assertion := range invalids {
+		if error := assertion.Validate(); error == nil {
+			t.Error("Expected error for '", assertion.String(), "', got nil")
+		}
+	}
+}
+
+func TestFailedAssertions(t *testing.T) {
+	results := EvaluateAssertions([]Assertion{
+		NewAssertion(AssertStatusEquals, "", "200"),
+		NewAssertion(AssertStatusEquals, "", "404"),
+	}, ResponseData{StatusCode: 200})
+
+	if actual := FailedAssertions(results); actual != 1 {
+		t.Error("Expected 1, got ", actual)
+	}
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"errors"
+
+	"github.com/joakim-ribier/gttp/core"
+)
+
+// Represents the authentication types (stable identifiers saved in the data file, see the view for the labels)
+const (
+	AuthNone         = "none"
+	AuthBasic        = "basic"
+	AuthBearer       = "bearer"
+	AuthAPIKeyHeader = "apikey-header"
+	AuthAPIKeyQuery  = "apikey-query"
+	AuthDigest       = "digest"
+)
+
+// AuthTypes represents all the authentication types
+var AuthTypes = core.StringSlice{AuthNone, AuthBasic, AuthBearer, AuthAPIKeyHeader, AuthAPIKeyQuery, AuthDigest}
+
+// Auth represents the authentication of a request, the values can contain context variables ("{token}")
+// - Basic & Digest => Username & Password
+// - Bearer token   => Token
+// - API key        => Key (header or query param name) & Token
+type Auth struct {
+	Type     string
+	Username string
+	Password string
+	Token    string
+	Key      string
+}
+
+// IsEmpty returns true if there is no authentication
+func (a Auth) IsEmpty() bool {
+	return a.Type == "" || a.Type == AuthNone
+}
+
+// Principal returns the non secret value which identifies the authentication (username or key name)
+func (a Auth) Principal() string {
+	switch a.Type {
+	case AuthBasic, AuthDigest:
+		return a.Username
+	case AuthAPIKeyHeader, AuthAPIKeyQuery:
+		return a.Key
+	default:
+		return ""
+	}
+}
+
+// ReplaceContext replaces the context variables (@contextValues) of the authentication values
+func (a Auth) ReplaceContext(contextValues map[string]string) Auth {
+	replace := func(value string) string {
+		return core.ReplaceContext(value, contextValues)
+	}
+	return Auth{
+		Type:     a.Type,
+		Username: replace(a.Username),
+		Password: replace(a.Password),
+		Token:    replace(a.Token),
+		Key:      replace(a.Key),
+	}
+}
+
+// Validate checks that the authentication is well defined
+func (a Auth) Validate() error {
+	switch a.Type {
+	case "", AuthNone:
+		return nil
+	case AuthBasic, AuthDigest:
+		if a.Username == "" {
+			return errors.New("the username is required")
+		}
+	case AuthBearer:
+		if a.Token == "" {
+			return errors.New("the token is required")
+		}
+	case AuthAPIKeyHeader, AuthAPIKeyQuery:
+		if a.Key == "" || a.Token == "" {
+			return errors.New("the key name and its value are required")
+		}
+	default:
+		return errors.New("unknown authentication '" + a.Type + "'")
+	}
+	return nil
+}
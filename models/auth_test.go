@@ -0,0 +1,85 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/joakim-ribier/gttp/core"
+)
+
+// Test 'ReplaceContext' method
+func TestAuthReplaceContext(t *testing.T) {
+	auth := Auth{Type: AuthBasic, Username: "{user}", Password: "pwd-{password}", Token: "{unknown}", Key: "X-Key"}
+
+	expected := Auth{Type: AuthBasic, Username: "bob", Password: "pwd-secret", Token: "{unknown}", Key: "X-Key"}
+	if actual := auth.ReplaceContext(map[string]string{"{user}": "bob", "{password}": "secret", "host": "localhost"}); actual != expected {
+		t.Error("Expected ", expected, ", got ", actual)
+	}
+}
+
+// Test 'Validate' method
+func TestAuthValidate(t *testing.T) {
+	for _, auth := range []Auth{{}, {Type: AuthNone}, {Type: AuthBasic, Username: "bob"}, {Type: AuthBearer, Token: "abc"}, {Type: AuthAPIKeyQuery, Key: "api_key", Token: "abc"}} {
+		if error := auth.Validate(); error != nil {
+			t.Error("Expected nil, got ", error)
+		}
+	}
+
+	for _, auth := range []Auth{{Type: AuthDigest}, {Type: AuthBearer}, {Type: AuthAPIKeyHeader, Token: "abc"}, {Type: "OAuth"}} {
+		if error := auth.Validate(); error == nil {
+			t.Error("Expected error for ", auth, ", got nil")
+		}
+	}
+}
+
+// Test 'Principal' method
+func TestAuthPrincipal(t *testing.T) {
+	values := map[string]Auth{
+		"":      {Type: AuthNone},
+		"bob":   {Type: AuthBasic, Username: "bob", Password: "secret"},
+		"X-Key": {Type: AuthAPIKeyHeader, Key: "X-Key", Token: "secret"},
+	}
+	for expected, auth := range values {
+		if actual := auth.Principal(); actual != expected {
+			t.Error("Expected ", expected, ", got ", actual)
+		}
+	}
+	if actual := (Auth{Type: AuthBearer, Token: "secret"}).Principal(); actual != "" {
+		t.Error("Expected '', got ", actual)
+	}
+}
+
+// Test 'WithAuthHeaders' method
+func TestWithAuthHeaders(t *testing.T) {
+	request := ResolvedRequest{URL: "http://localhost/users?page=1#top", Headers: core.StringMap{"Accept": "*/*"}}
+
+	tests := []struct {
+		auth     Auth
+		header   string
+		value    string
+		expected string
+	}{
+		{Auth{Type: AuthBasic, Username: "bob", Password: "secret"}, "Authorization", "Basic Ym9iOnNlY3JldA==", "http://localhost/users?page=1#top"},
+		{Auth{Type: AuthBearer, Token: "abc"}, "Authorization", "Bearer abc", "http://localhost/users?page=1#top"},
+		{Auth{Type: AuthAPIKeyHeader, Key: "X-API-Key", Token: "abc"}, "X-API-Key", "abc", "http://localhost/users?page=1#top"},
+		{Auth{Type: AuthAPIKeyQuery, Key: "api_key", Token: "a&b"}, "", "", "http://localhost/users?page=1&api_key=a%26b#top"},
+	}
+	for _, test := range tests {
+		request.Auth = test.auth
+		actual := request.WithAuthHeaders()
+
+		if actual.URL.String() != test.expected || !actual.Auth.IsEmpty() || actual.Headers["Accept"] != "*/*" {
+			t.Error("Expected ", test.expected, ", got ", actual)
+		}
+		if test.header != "" && actual.Headers[test.header] != test.value {
+			t.Error("Expected ", test.value, ", got ", actual.Headers)
+		}
+	}
+	if len(request.Headers) != 1 {
+		t.Error("Expected the original headers unchanged, got ", request.Headers)
+	}
+
+	request.Auth = Auth{Type: AuthDigest, Username: "bob"}
+	if actual := request.WithAuthHeaders(); actual.Auth != request.Auth {
+		t.Error("Expected the Digest authentication kept, got ", actual.Auth)
+	}
+}
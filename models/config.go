@@ -3,4 +3,7 @@ package models
 // Config contains the app configuration
 type Config struct {
 	Pattern string
+	Timeout Timeout
+	TLS     TLS
+	Proxy   Proxy
 }
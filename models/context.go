@@ -11,13 +11,18 @@ const defaultValue = "default"
 
 // Context reprensents a context structure
 type Context struct {
-	Env map[string][]ContextVariable
+	Env    map[string][]ContextVariable
+	TLS    map[string]TLS
+	Proxy  map[string]Proxy
+	OAuth2 map[string]OAuth2
 }
 
-// ContextVariable reprensents a context variable structure
+// ContextVariable reprensents a context variable structure,
+// the value of a secret variable is not in the context (see Secrets)
 type ContextVariable struct {
 	Variable string
 	Value    string
+	Secret   bool
 }
 
 // NewContextVariable creates new ContextVariable struct
@@ -28,6 +33,51 @@ func NewContextVariable(variable string, value string) ContextVariable {
 	}
 }
 
+// ContextVariableName formats the @name as a context variable ("Token" => "{token}")
+func ContextVariableName(name string) string {
+	name = strings.TrimSpace(name)
+	if name != "" && !(strings.HasPrefix(name, "{") && strings.HasSuffix(name, "}")) {
+		name = "{" + name + "}"
+	}
+	return strings.ToLower(name)
+}
+
+// GetProxy returns the proxy settings of an environment (empty if not defined)
+func (c Context) GetProxy(env string) Proxy {
+	return c.Proxy[strings.ToLower(env)]
+}
+
+// SetProxy sets (or removes if empty) the proxy settings of an environment
+func (c *Context) SetProxy(env string, proxy Proxy) {
+	env = strings.ToLower(env)
+	if proxy.IsEmpty() {
+		delete(c.Proxy, env)
+		return
+	}
+	if c.Proxy == nil {
+		c.Proxy = make(map[string]Proxy)
+	}
+	c.Proxy[env] = proxy
+}
+
+// GetOAuth2 returns the OAuth2 profile of an environment (empty if not defined)
+func (c Context) GetOAuth2(env string) OAuth2 {
+	return c.OAuth2[strings.ToLower(env)]
+}
+
+// SetOAuth2 sets (or removes if empty) the OAuth2 profile of an environment
+func (c *Context) SetOAuth2(env string, oauth2 OAuth2) {
+	env = strings.ToLower(env)
+	if oauth2 == (OAuth2{}) {
+		delete(c.OAuth2, env)
+		return
+	}
+	if c.OAuth2 == nil {
+		c.OAuth2 = make(map[string]OAuth2)
+	}
+	c.OAuth2[env] = oauth2
+}
+
 // GetEnvsName gets all environments name
 func (c Context) GetEnvsName() core.StringSlice {
 	var tab core.StringSlice = []string{}
@@ -41,17 +91,68 @@ func (c Context) GetEnvsName() core.StringSlice {
 	return tab
 }
 
+// Copy returns a deep copy of the context (the updates of the copy don't change the original context)
+func (c Context) Copy() Context {
+	new := Context{Env: make(map[string][]ContextVariable)}
+	for env, variables := range c.Env {
+		new.Env[env] = append([]ContextVariable{}, variables...)
+	}
+	for env, tls := range c.TLS {
+		if new.TLS == nil {
+			new.TLS = make(map[string]TLS)
+		}
+		new.TLS[env] = tls
+	}
+	for env, proxy := range c.Proxy {
+		if new.Proxy == nil {
+			new.Proxy = make(map[string]Proxy)
+		}
+		new.Proxy[env] = proxy
+	}
+	for env, oauth2 := range c.OAuth2 {
+		if new.OAuth2 == nil {
+			new.OAuth2 = make(map[string]OAuth2)
+		}
+		new.OAuth2[env] = oauth2
+	}
+	return new
+}
+
+// GetTLS returns the TLS settings of an environment (empty if not defined)
+func (c Context) GetTLS(env string) TLS {
+	return c.TLS[strings.ToLower(env)]
+}
+
+// SetTLS sets (or removes if empty) the TLS settings of an environment
+func (c *Context) SetTLS(env string, tls TLS) {
+	env = strings.ToLower(env)
+	if tls.IsEmpty() {
+		delete(c.TLS, env)
+		return
+	}
+	if c.TLS == nil {
+		c.TLS = make(map[string]TLS)
+	}
+	c.TLS[env] = tls
+}
+
 // Add adds new variable to an environment
 func (c *Context) Add(env string, variable string, value string) {
-	c.add(strings.ToLower(env), strings.ToLower(variable), value)
+	c.add(strings.ToLower(env), NewContextVariable(strings.ToLower(variable), value))
 }
 
-func (c *Context) add(env string, variable string, value string) {
-	add := func(variable string, value string, slice []ContextVariable) []ContextVariable {
+// AddSecret adds new secret variable (without its value) to an environment
+func (c *Context) AddSecret(env string, variable string) {
+	c.add(strings.ToLower(env), ContextVariable{Variable: strings.ToLower(variable), Secret: true})
+}
+
+func (c *Context) add(env string, contextVariable ContextVariable) {
+	add := func(contextVariable ContextVariable, slice []ContextVariable) []ContextVariable {
+		variable := contextVariable.Variable
 		if slice == nil {
-			return []ContextVariable{NewContextVariable(variable, value)}
+			return []ContextVariable{contextVariable}
 		}
-		newSlice := []ContextVariable{NewContextVariable(variable, value)}
+		newSlice := []ContextVariable{contextVariable}
 		for _, value := range slice {
 			if value.Variable != variable {
 				newSlice = append(newSlice, value)
@@ -62,7 +163,7 @@ func (c *Context) add(env string, variable string, value string) {
 	if c.Env == nil {
 		c.Env = make(map[string][]ContextVariable)
 	}
-	c.Env[env] = add(variable, value, c.Env[env])
+	c.Env[env] = add(contextVariable, c.Env[env])
 }
 
 // Remove removes a variable to an environment
@@ -93,11 +194,24 @@ func (c Context) GetAllVariables(env string) core.StringSlice {
 	return newSlice
 }
 
-// GetAllKeyValue gets all ContextVariable for an specific environment
+// GetSecretVariables returns the secret variables for an specific environment
+func (c Context) GetSecretVariables(env string) core.StringSlice {
+	newSlice := []string{}
+	for _, value := range c.Env[env] {
+		if value.Secret {
+			newSlice = append(newSlice, value.Variable)
+		}
+	}
+	return newSlice
+}
+
+// GetAllKeyValue gets all ContextVariable for an specific environment (except the secret ones)
 func (c Context) GetAllKeyValue(env string) map[string]string {
 	newMap := make(map[string]string)
 	for _, value := range c.Env[env] {
-		newMap[value.Variable] = value.Value
+		if !value.Secret {
+			newMap[value.Variable] = value.Value
+		}
 	}
 	return newMap
 }
@@ -196,3 +196,23 @@ func testLength(values []ContextVariable, expected int) func(*testing.T) {
 		}
 	}
 }
+
+func TestContextVariableName(t *testing.T) {
+	values := map[string]string{"Token": "{token}", " {Id} ": "{id}", "": ""}
+	for value, expected := range values {
+		if actual := ContextVariableName(value); actual != expected {
+			t.Error("Expected ", expected, ", got ", actual)
+		}
+	}
+}
+
+// Test 'GetSecretVariables' method
+func TestGetSecretVariables(t *testing.T) {
+	ctx := Context{}
+	ctx.Add("dev", "{host}", "localhost")
+	ctx.AddSecret("dev", "{token}")
+
+	if actual := ctx.GetSecretVariables("dev"); len(actual) != 1 || actual[0] != "{token}" {
+		t.Error("Expected [{token}], got ", actual)
+	}
+}
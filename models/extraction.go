@@ -0,0 +1,122 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/joakim-ribier/gttp/core"
+)
+
+// Represents the extraction sources
+const (
+	ExtractJSONPath = "JSONPath"
+	ExtractHeader   = "Header"
+	ExtractRegex    = "Regex"
+)
+
+// ExtractionSources contains all the extraction sources
+var ExtractionSources = core.StringSlice{
+	ExtractJSONPath,
+	ExtractHeader,
+	ExtractRegex,
+}
+
+// Extraction represents a rule which extracts a value from the response into a context variable
+// (@Expression is the JSONPath, the header name or the regex applied to the body)
+type Extraction struct {
+	Source     string
+	Expression string
+	Variable   string
+}
+
+// ExtractionResult represents the result of an applied extraction rule
+type ExtractionResult struct {
+	Extraction Extraction
+	Value      string
+	Error      string
+}
+
+// NewExtraction creates a new Extraction struct, the variable is formatted as a context variable ("token" => "{token}")
+func NewExtraction(source string, expression string, variable string) Extraction {
+	return Extraction{
+		Source:     source,
+		Expression: expression,
+		Variable:   ContextVariableName(variable),
+	}
+}
+
+// String returns the extraction as a readable string
+func (e Extraction) String() string {
+	return e.Source + " " + e.Expression + " => " + e.Variable
+}
+
+// Validate checks that the extraction rule is well defined
+func (e Extraction) Validate() error {
+	if e.Variable == "" || e.Variable == "{}" {
+		return errors.New("empty variable")
+	}
+	if e.Expression == "" {
+		return errors.New("empty expression")
+	}
+	switch e.Source {
+	case ExtractJSONPath, ExtractHeader:
+	case ExtractRegex:
+		if _, error := regexp.Compile(e.Expression); error != nil {
+			return errors.New("invalid regex '" + e.Expression + "'")
+		}
+	default:
+		return errors.New("unknown extraction source '" + e.Source + "'")
+	}
+	return nil
+}
+
+// Extract extracts the value from the @response,
+// the regex returns the first group (or the whole match if the regex has no group)
+func (e Extraction) Extract(response ResponseData) (string, error) {
+	if error := e.Validate(); error != nil {
+		return "", error
+	}
+
+	switch e.Source {
+	case ExtractJSONPath:
+		value, error := core.JSONPath(response.Body, e.Expression)
+		if error != nil {
+			return "", error
+		}
+		return core.JSONValueToString(value), nil
+	case ExtractHeader:
+		if values := response.Headers.Values(e.Expression); len(values) > 0 {
+			return values[0], nil
+		}
+		return "", errors.New("header '" + e.Expression + "' not found")
+	default:
+		match := regexp.MustCompile(e.Expression).FindSubmatch(response.Body)
+		if match == nil {
+			return "", errors.New("no match for '" + e.Expression + "'")
+		}
+		if len(match) > 1 {
+			return string(match[1]), nil
+		}
+		return string(match[0]), nil
+	}
+}
+
+// ApplyExtractions extracts all the values of the @extractions from the @response and adds them to the @env of the @context,
+// the variables of the failed extractions are not updated and a secret variable is never overwritten (stored as plain text)
+func ApplyExtractions(extractions []Extraction, response ResponseData, context *Context, env string) []ExtractionResult {
+	var results []ExtractionResult
+	for _, extraction := range extractions {
+		result := ExtractionResult{Extraction: extraction}
+		if context.FindVariableByEnv(strings.ToLower(env), extraction.Variable).Secret {
+			result.Error = "'" + extraction.Variable + "' is a secret variable"
+		} else if value, error := extraction.Extract(response); error != nil {
+			result.Error = error.Error()
+		} else {
+			result.Value = value
+			context.Add(env, extraction.Variable, value)
+		}
+		results = append(results, result)
+	}
+	return results
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNewExtraction(t *testing.T) {
+	if actual := NewExtraction(ExtractJSONPath, "$.token", "Token").Variable; actual != "{token}" {
+		t.Error("Expected {token}, got ", actual)
+	}
+	if actual := NewExtraction(ExtractJSONPath, "$.token", "{token}").Variable; actual != "{token}" {
+		t.Error("Expected {token}, got ", actual)
+	}
+}
+
+func TestExtractionExtract(t *testing.T) {
+	response := ResponseData{
+		Headers: http.Header{"Location": {"/users/42"}},
+		Body:    []byte(`{"access_token": "abc.def", "expires_in": 3600}`),
+	}
+
+	values := map[Extraction]string{
+		NewExtraction(ExtractJSONPath, "$.access_token", "token"):     "abc.def",
+		NewExtraction(ExtractJSONPath, "expires_in", "expires"):       "3600",
+		NewExtraction(ExtractHeader, "location", "location"):          "/users/42",
+		NewExtraction(ExtractRegex, `"expires_in": (\d+)`, "expires"): "3600",
+		NewExtraction(ExtractRegex, `abc\.\w+`, "token"):              "abc.def",
+	}
+	for extraction, expected := range values {
+		if actual, error := extraction.Extract(response); error != nil || actual != expected {
+			t.Error("Expected ", expected, " for '", extraction.String(), "', got ", actual, error)
+		}
+	}
+
+	errors := []Extraction{
+		NewExtraction(ExtractJSONPath, "$.unknown", "token"),
+		NewExtraction(ExtractHeader, "X-Unknown", "token"),
+		NewExtraction(ExtractRegex, "unknown", "token"),
+		NewExtraction(ExtractRegex, "(", "token"),
+		NewExtraction(ExtractJSONPath, "$.access_token", ""),
+	}
+	for _, extraction := range errors {
+		if _, error := extraction.Extract(response); error == nil {
+			t.Error("Expected error for '", extraction.String(), "', got nil")
+		}
+	}
+}
+
+func TestApplyExtractions(t *testing.T) {
+	context := Context{}
+	context.Add("prod", "{token}", "old")
+	context.Add("prod", "{user}", "gttp")
+
+	results := ApplyExtractions([]Extraction{
+		NewExtraction(ExtractJSONPath, "$.token", "token"),
+		NewExtraction(ExtractJSONPath, "$.unknown", "user"),
+	}, ResponseData{Body: []byte(`{"token": "new"}`)}, &context, "Prod")
+
+	values := context.GetAllKeyValue("prod")
+	if values["{token}"] != "new" || values["{user}"] != "gttp" {
+		t.Error("Expected {token}=new & {user}=gttp, got ", values)
+	}
+	if results[0].Value != "new" || results[1].Error == "" {
+		t.Error("Expected 1 extracted value & 1 error, got ", results)
+	}
+}
+
+func TestApplyExtractionsSecret(t *testing.T) {
+	context := Context{}
+	context.AddSecret("prod", "{token}")
+
+	results := ApplyExtractions([]Extraction{
+		NewExtraction(ExtractJSONPath, "$.token", "token"),
+	}, ResponseData{Body: []byte(`{"token": "new"}`)}, &context, "prod")
+
+	if variable := context.FindVariableByEnv("prod", "{token}"); !variable.Secret || variable.Value != "" {
+		t.Error("Expected {token} still secret without value, got ", variable)
+	}
+	if results[0].Error == "" {
+		t.Error("Expected error, got ", results)
+	}
+}
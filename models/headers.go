@@ -0,0 +1,69 @@
+package models
+
+import "github.com/joakim-ribier/gttp/core"
+
+// GetHeaders returns all the headers of the request (enabled & disabled)
+func (m MakeRequestData) GetHeaders() core.StringMap {
+	headers := make(core.StringMap)
+	for key, value := range m.MapDisabledHeaderKeyValue {
+		headers[key] = value
+	}
+	for key, value := range m.MapRequestHeaderKeyValue {
+		headers[key] = value
+	}
+	return headers
+}
+
+// IsHeaderEnabled returns false if the header @key is disabled (kept in the request but not sent)
+func (m MakeRequestData) IsHeaderEnabled(key string) bool {
+	if _, enabled := m.MapRequestHeaderKeyValue[key]; enabled {
+		return true
+	}
+	_, disabled := m.MapDisabledHeaderKeyValue[key]
+	return !disabled
+}
+
+// SetHeader adds or replaces the header @key, a disabled header is kept with its value but not sent
+func (m MakeRequestData) SetHeader(key string, value string, enabled bool) MakeRequestData {
+	m = m.RemoveHeader(key)
+	if enabled {
+		m.MapRequestHeaderKeyValue[key] = value
+	} else {
+		if m.MapDisabledHeaderKeyValue == nil {
+			m.MapDisabledHeaderKeyValue = make(core.StringMap)
+		}
+		m.MapDisabledHeaderKeyValue[key] = value
+	}
+	return m
+}
+
+// RemoveHeader removes the header @key (enabled or disabled)
+func (m MakeRequestData) RemoveHeader(key string) MakeRequestData {
+	headers := make(core.StringMap)
+	for k, v := range m.MapRequestHeaderKeyValue {
+		if k != key {
+			headers[k] = v
+		}
+	}
+	var disabled core.StringMap
+	for k, v := range m.MapDisabledHeaderKeyValue {
+		if k != key {
+			if disabled == nil {
+				disabled = make(core.StringMap)
+			}
+			disabled[k] = v
+		}
+	}
+	m.MapRequestHeaderKeyValue = headers
+	m.MapDisabledHeaderKeyValue = disabled
+	return m
+}
+
+// SetHeaderEnabled enables or disables the header @key (the header moves with its value)
+func (m MakeRequestData) SetHeaderEnabled(key string, enabled bool) MakeRequestData {
+	value, exists := m.GetHeaders()[key]
+	if !exists {
+		return m
+	}
+	return m.SetHeader(key, value, enabled)
+}
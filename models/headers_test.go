@@ -0,0 +1,47 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/joakim-ribier/gttp/core"
+)
+
+// Test 'SetHeaderEnabled' & 'GetHTTPHeaderValues' methods (the disabled headers are kept but not sent)
+func TestSetHeaderEnabled(t *testing.T) {
+	mrd := NewMakeRequestData("GET", "http://localhost", core.StringMap{"Authorization": "Bearer {token}", "If-None-Match": "abc", "X-Request-Id": "42"}, "", "application/json", "", "")
+
+	mrd = mrd.SetHeaderEnabled("Authorization", false).SetHeaderEnabled("If-None-Match", false)
+
+	if mrd.IsHeaderEnabled("Authorization") || mrd.IsHeaderEnabled("If-None-Match") || !mrd.IsHeaderEnabled("X-Request-Id") {
+		t.Error("Expected 'Authorization' & 'If-None-Match' disabled, got ", mrd.MapDisabledHeaderKeyValue)
+	}
+	if expected := (core.StringMap{"X-Request-Id": "42"}); !reflect.DeepEqual(mrd.GetHTTPHeaderValues(), expected) {
+		t.Error("Expected ", expected, ", got ", mrd.GetHTTPHeaderValues())
+	}
+	if len(mrd.GetHeaders()) != 3 {
+		t.Error("Expected the disabled headers to be kept, got ", mrd.GetHeaders())
+	}
+
+	mrd = mrd.SetHeaderEnabled("Authorization", true).SetHeaderEnabled("If-None-Match", true)
+
+	if mrd.MapDisabledHeaderKeyValue != nil || len(mrd.GetHTTPHeaderValues()) != 3 {
+		t.Error("Expected all the headers enabled, got ", mrd.MapDisabledHeaderKeyValue)
+	}
+}
+
+// Test 'SetHeader' & 'RemoveHeader' methods (the enabled flag stays with the header)
+func TestSetHeader(t *testing.T) {
+	mrd := NewMakeRequestData("GET", "http://localhost", core.StringMap{"X-Request-Id": "42"}, "", "application/json", "", "")
+
+	mrd = mrd.SetHeader("If-None-Match", "abc", false).SetHeader("If-None-Match", "def", false)
+	if mrd.IsHeaderEnabled("If-None-Match") || mrd.GetHeaders()["If-None-Match"] != "def" {
+		t.Error("Expected 'If-None-Match: def' disabled, got ", mrd.GetHeaders())
+	}
+
+	// a removed header doesn't leave its flag behind
+	mrd = mrd.RemoveHeader("If-None-Match").SetHeader("If-None-Match", "abc", true)
+	if !mrd.IsHeaderEnabled("If-None-Match") || len(mrd.GetHTTPHeaderValues()) != 2 {
+		t.Error("Expected 2 enabled headers, got ", mrd.GetHTTPHeaderValues())
+	}
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// Represents the history limits
+const (
+	HistoryMaxEntries      = 200
+	HistoryMaxResponseSize = 64 * 1024
+)
+
+// History contains all executed requests (the last one first)
+type History struct {
+	Entries []HistoryEntry
+}
+
+// HistoryEntry represents an executed request
+type HistoryEntry struct {
+	Date       time.Time
+	Request    MakeRequestData
+	Context    string
+	Method     string
+	URL        string
+	Headers    map[string]string
+	Body       string
+	Status     string
+	StatusCode int
+	Duration   time.Duration
+	Response   string
+	Truncated  bool
+	Error      string
+}
+
+// NewHistoryEntry creates a new HistoryEntry struct, the response is truncated to HistoryMaxResponseSize
+func NewHistoryEntry(
+	request MakeRequestData,
+	context string,
+	url string,
+	headers map[string]string,
+	duration time.Duration,
+	statusCode int,
+	status string,
+	response []byte) HistoryEntry {
+
+	entry := HistoryEntry{
+		Date:       time.Now(),
+		Request:    request,
+		Context:    context,
+		Method:     request.Method.String(),
+		URL:        url,
+		Headers:    headers,
+		Body:       request.Body,
+		Status:     status,
+		StatusCode: statusCode,
+		Duration:   duration,
+	}
+	if len(response) > HistoryMaxResponseSize {
+		entry.Response = string(response[:HistoryMaxResponseSize])
+		entry.Truncated = true
+	} else {
+		entry.Response = string(response)
+	}
+	return entry
+}
+
+// Add adds a new entry on the top of the history and removes the oldest ones
+func (h *History) Add(entry HistoryEntry) {
+	h.Entries = append([]HistoryEntry{entry}, h.Entries...)
+	if len(h.Entries) > HistoryMaxEntries {
+		h.Entries = h.Entries[:HistoryMaxEntries]
+	}
+}
+
+// Clear removes all entries
+func (h *History) Clear() {
+	h.Entries = []HistoryEntry{}
+}
+
+// StatusLabel returns the status (or the error) to display
+func (e HistoryEntry) StatusLabel() string {
+	if e.Error != "" {
+		return e.Error
+	}
+	if e.Status != "" {
+		return e.Status
+	}
+	return strconv.Itoa(e.StatusCode)
+}
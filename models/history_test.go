@@ -0,0 +1,33 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test 'NewHistoryEntry' method
+func TestNewHistoryEntryTruncatesResponse(t *testing.T) {
+	response := []byte(strings.Repeat("a", HistoryMaxResponseSize+10))
+
+	entry := NewHistoryEntry(EmptyMakeRequestData(), "default", "http://localhost", nil, 0, 200, "200 OK", response)
+
+	if len(entry.Response) != HistoryMaxResponseSize || !entry.Truncated {
+		t.Error("Expected truncated response, got ", len(entry.Response), entry.Truncated)
+	}
+}
+
+// Test 'Add' method
+func TestHistoryAdd(t *testing.T) {
+	var history History
+	for i := 0; i < HistoryMaxEntries+5; i++ {
+		history.Add(HistoryEntry{URL: "old"})
+	}
+	history.Add(HistoryEntry{URL: "new"})
+
+	if len(history.Entries) != HistoryMaxEntries {
+		t.Error("Expected len(", HistoryMaxEntries, "), got ", len(history.Entries))
+	}
+	if history.Entries[0].URL != "new" {
+		t.Error("Expected 'new' first entry, got ", history.Entries[0].URL)
+	}
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/joakim-ribier/gttp/core"
+)
+
+// Represents the OAuth2 grant types
+const (
+	OAuth2ClientCredentials = "client_credentials"
+	OAuth2Password          = "password"
+)
+
+// OAuth2GrantTypes represents the supported OAuth2 grant types
+var OAuth2GrantTypes = core.StringSlice{OAuth2ClientCredentials, OAuth2Password}
+
+// OAuth2 represents the OAuth2 profile of an execution context, the token is fetched before
+// the requests and sent as a Bearer token (the values can contain context variables)
+type OAuth2 struct {
+	GrantType    string
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       string
+	Username     string
+	Password     string
+}
+
+// IsEmpty returns true if no OAuth2 profile is defined
+func (o OAuth2) IsEmpty() bool {
+	return o.TokenURL == ""
+}
+
+// ReplaceContext replaces the context variables (@contextValues) of the profile values
+func (o OAuth2) ReplaceContext(contextValues map[string]string) OAuth2 {
+	replace := func(value string) string {
+		return core.ReplaceContext(value, contextValues)
+	}
+	return OAuth2{
+		GrantType:    o.GrantType,
+		TokenURL:     replace(o.TokenURL),
+		ClientID:     replace(o.ClientID),
+		ClientSecret: replace(o.ClientSecret),
+		Scopes:       replace(o.Scopes),
+		Username:     replace(o.Username),
+		Password:     replace(o.Password),
+	}
+}
+
+// Validate checks that the profile is well defined (an empty profile is valid)
+func (o OAuth2) Validate() error {
+	if o == (OAuth2{}) {
+		return nil
+	}
+	if OAuth2GrantTypes.GetIndex(o.GrantType) == -1 {
+		return errors.New("unknown grant type '" + o.GrantType + "'")
+	}
+	value, error := url.Parse(o.TokenURL)
+	if error != nil || value.Host == "" || (value.Scheme != "http" && value.Scheme != "https") {
+		return errors.New("invalid token URL '" + o.TokenURL + "'")
+	}
+	if o.ClientID == "" {
+		return errors.New("the client id is required")
+	}
+	if o.GrantType == OAuth2Password && o.Username == "" {
+		return errors.New("the username is required by the password grant")
+	}
+	return nil
+}
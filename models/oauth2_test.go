@@ -0,0 +1,58 @@
+package models
+
+import (
+	"testing"
+)
+
+// Test 'Validate' method
+func TestOAuth2Validate(t *testing.T) {
+	valid := []OAuth2{
+		{},
+		{GrantType: OAuth2ClientCredentials, TokenURL: "https://auth.local/token", ClientID: "gttp"},
+		{GrantType: OAuth2Password, TokenURL: "http://localhost:8080/token", ClientID: "gttp", Username: "bob"},
+	}
+	for _, oauth2 := range valid {
+		if error := oauth2.Validate(); error != nil {
+			t.Error("Expected nil, got ", error)
+		}
+	}
+
+	invalid := []OAuth2{
+		{GrantType: "implicit", TokenURL: "https://auth.local/token", ClientID: "gttp"},
+		{GrantType: OAuth2ClientCredentials, TokenURL: "auth.local/token", ClientID: "gttp"},
+		{GrantType: OAuth2ClientCredentials, TokenURL: "https://auth.local/token"},
+		{GrantType: OAuth2Password, TokenURL: "https://auth.local/token", ClientID: "gttp"},
+	}
+	for _, oauth2 := range invalid {
+		if error := oauth2.Validate(); error == nil {
+			t.Error("Expected error for ", oauth2, ", got nil")
+		}
+	}
+}
+
+// Test 'ReplaceContext' method
+func TestOAuth2ReplaceContext(t *testing.T) {
+	oauth2 := OAuth2{GrantType: OAuth2ClientCredentials, TokenURL: "{auth}/token", ClientID: "gttp", ClientSecret: "{secret}"}
+
+	expected := OAuth2{GrantType: OAuth2ClientCredentials, TokenURL: "https://auth.local/token", ClientID: "gttp", ClientSecret: "s3cret"}
+	if actual := oauth2.ReplaceContext(map[string]string{"{auth}": "https://auth.local", "{secret}": "s3cret"}); actual != expected {
+		t.Error("Expected ", expected, ", got ", actual)
+	}
+}
+
+// Test 'SetOAuth2' & 'Copy' methods
+func TestContextOAuth2(t *testing.T) {
+	context := Context{}
+	oauth2 := OAuth2{GrantType: OAuth2ClientCredentials, TokenURL: "https://auth.local/token", ClientID: "gttp"}
+
+	context.SetOAuth2("DEV", oauth2)
+	copy := context.Copy()
+	context.SetOAuth2("dev", OAuth2{})
+
+	if actual := context.GetOAuth2("dev"); actual != (OAuth2{}) {
+		t.Error("Expected empty profile, got ", actual)
+	}
+	if actual := copy.GetOAuth2("dev"); actual != oauth2 {
+		t.Error("Expected ", oauth2, ", got ", actual)
+	}
+}
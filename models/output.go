@@ -3,6 +3,7 @@ package models
 import (
 	"errors"
 	"sort"
+	"strconv"
 )
 
 // Output struct corresponds to serialize and deserialize json app file
@@ -12,32 +13,64 @@ type Output struct {
 	Context Context
 }
 
+// Normalize normalizes all the requests (see MakeRequestData.Normalized)
+func (out *Output) Normalize() {
+	for index, data := range out.Data {
+		out.Data[index] = data.Normalized()
+	}
+}
+
+// IsSameRequest returns true if the requests have the same primary key (method, URL, project name & alias),
+// several requests can share the same method & URL (ex. the same endpoint with different bodies)
+func (m MakeRequestData) IsSameRequest(other MakeRequestData) bool {
+	return m.Method == other.Method && m.URL == other.URL && m.ProjectName == other.ProjectName && m.Alias == other.Alias
+}
+
 // AddOrReplace adds or replaces a MakeRequestData struct
 func (out *Output) AddOrReplace(data MakeRequestData) {
 	// Initialize with the updated data
 	newData := []MakeRequestData{data}
 	for _, value := range out.Data {
-		// URL & Method are the primary key
-		if value.URL != data.URL || value.Method != data.Method {
+		if !value.IsSameRequest(data) {
 			newData = append(newData, value)
 		}
 	}
 	out.Data = newData
 }
 
+// UpdateResponseFilter updates the response filter of the saved request (returns false if the request does not exist)
+func (out *Output) UpdateResponseFilter(data MakeRequestData, expression string) bool {
+	for index, value := range out.Data {
+		if value.IsSameRequest(data) {
+			out.Data[index].ResponseFilter = expression
+			return true
+		}
+	}
+	return false
+}
+
 // Remove removes MakeRequestData struct
 func (out *Output) Remove(data MakeRequestData) {
 	newData := []MakeRequestData{}
 	for _, value := range out.Data {
-		// URL & Method are the primary key
-		if !(value.URL == data.URL && value.Method == data.Method) {
+		if !value.IsSameRequest(data) {
 			newData = append(newData, value)
 		}
 	}
 	out.Data = newData
 }
 
-// Find finds a MakeRequestData from "method"/"url"
+// FindRequest finds the saved version of the @data (see IsSameRequest)
+func (out Output) FindRequest(data MakeRequestData) (MakeRequestData, error) {
+	for _, value := range out.Data {
+		if value.IsSameRequest(data) {
+			return value, nil
+		}
+	}
+	return MakeRequestData{}, errors.New("'" + data.Method.String() + " " + data.URL.String() + "' value does not exist")
+}
+
+// Find finds the first MakeRequestData from "method"/"url"
 func (out Output) Find(method string, url string) (MakeRequestData, error) {
 	var find MakeRequestData
 	for _, value := range out.Data {
@@ -89,3 +122,42 @@ func (out Output) SortDataAPIsByProjectName() ([]string, map[string][]MakeReques
 func (out Output) UpdateMakeRequestData(values []MakeRequestData) Output {
 	return Output{values, out.Config, out.Context}
 }
+
+// Merge adds or replaces the requests and the context variables of @other,
+// the requests of @other (in source order) are displayed after the existing ones (see SortDataAPIsByProjectName)
+func (out *Output) Merge(other Output) {
+	for _, data := range other.Data {
+		out.AddOrReplace(data)
+	}
+	for env, variables := range other.Context.Env {
+		for _, variable := range variables {
+			out.Context.Add(env, variable.Variable, variable.Value)
+		}
+	}
+}
+
+// UniqueAlias returns the @alias, or "alias (2)", "alias (3)"... if the project already has a request with this alias
+func (out Output) UniqueAlias(projectName string, alias string) string {
+	unique := alias
+	for index := 2; ; index++ {
+		if _, error := out.FindByAlias(projectName, unique); error != nil {
+			return unique
+		}
+		unique = alias + " (" + strconv.Itoa(index) + ")"
+	}
+}
+
+// FindByAlias finds a MakeRequestData from "project name"/"alias" (an empty project name means "no project")
+func (out Output) FindByAlias(projectName string, alias string) (MakeRequestData, error) {
+	var find MakeRequestData
+	if projectName == "." {
+		projectName = ""
+	}
+	for _, value := range out.Data {
+		if value.ProjectName == projectName && value.Alias == alias {
+			find = value
+			return find, nil
+		}
+	}
+	return find, errors.New("'" + projectName + " > " + alias + "' value does not exist")
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/joakim-ribier/gttp/core"
+	"github.com/joakim-ribier/gttp/models/types"
+)
+
+// QueryParam represents a query string parameter of the request, only the enabled ones are in the URL
+// (the key & the value are kept as typed, they can contain "{param}" or context variables)
+type QueryParam struct {
+	Key     string
+	Value   string
+	Enabled bool
+}
+
+// String returns the param as "key=value" (or "key" if no value)
+func (q QueryParam) String() string {
+	if q.Value == "" {
+		return q.Key
+	}
+	return q.Key + "=" + q.Value
+}
+
+// PathParamName formats the @name as a path param ("id" => "{id}")
+func PathParamName(name string) string {
+	name = strings.TrimSpace(name)
+	if name != "" && !(strings.HasPrefix(name, "{") && strings.HasSuffix(name, "}")) {
+		name = "{" + name + "}"
+	}
+	return name
+}
+
+// ParseQueryParams returns the (enabled) query params of the @url
+func ParseQueryParams(url types.URL) []QueryParam {
+	_, query, _ := splitURL(url.String())
+
+	var params []QueryParam
+	for _, value := range strings.Split(query, "&") {
+		if value == "" {
+			continue
+		}
+		param := QueryParam{Key: value, Enabled: true}
+		if index := strings.Index(value, "="); index != -1 {
+			param.Key, param.Value = value[:index], value[index+1:]
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// splitURL splits the @url in "base?query#fragment"
+func splitURL(url string) (string, string, string) {
+	var fragment string
+	if index := strings.Index(url, "#"); index != -1 {
+		url, fragment = url[:index], url[index:]
+	}
+	var query string
+	if index := strings.Index(url, "?"); index != -1 {
+		url, query = url[:index], url[index+1:]
+	}
+	return url, query, fragment
+}
+
+// SetURL updates the URL and the enabled query params from its query string (the disabled params are kept)
+func (m MakeRequestData) SetURL(url types.URL) MakeRequestData {
+	if url == m.URL && m.QueryParams != nil {
+		return m
+	}
+	params := ParseQueryParams(url)
+	for _, param := range m.QueryParams {
+		if !param.Enabled {
+			params = append(params, param)
+		}
+	}
+	m.URL = url
+	m.QueryParams = params
+	return m
+}
+
+// SetQueryParams updates the query params and the query string of the URL with the enabled ones
+func (m MakeRequestData) SetQueryParams(params []QueryParam) MakeRequestData {
+	var query []string
+	for _, param := range params {
+		if param.Enabled && param.Key != "" {
+			query = append(query, param.String())
+		}
+	}
+
+	base, _, fragment := splitURL(m.URL.String())
+	if len(query) > 0 {
+		base += "?" + strings.Join(query, "&")
+	}
+	m.URL = types.URL(base + fragment)
+	m.QueryParams = append([]QueryParam{}, params...)
+	return m
+}
+
+// Normalized returns a copy of the request where the legacy "{param}" keys of the headers are moved
+// to the path params and the query params are read from the URL if not defined
+func (m MakeRequestData) Normalized() MakeRequestData {
+	headers := make(core.StringMap)
+	pathParams := make(core.StringMap)
+	for key, value := range m.PathParams {
+		pathParams[key] = value
+	}
+	for key, value := range m.MapRequestHeaderKeyValue {
+		if strings.HasPrefix(key, "{") && strings.HasSuffix(key, "}") {
+			if _, exists := pathParams[key]; !exists {
+				pathParams[key] = value
+			}
+		} else {
+			headers[key] = value
+		}
+	}
+	m.MapRequestHeaderKeyValue = headers
+	m.PathParams = pathParams
+	if m.MapDisabledHeaderKeyValue != nil {
+		// a header is either enabled or disabled, the enabled one is kept
+		disabled := make(core.StringMap)
+		for key, value := range m.MapDisabledHeaderKeyValue {
+			if _, enabled := headers[key]; !enabled {
+				disabled[key] = value
+			}
+		}
+		m.MapDisabledHeaderKeyValue = disabled
+	}
+
+	if m.QueryParams == nil {
+		m.QueryParams = ParseQueryParams(m.URL)
+	} else {
+		m.QueryParams = append([]QueryParam{}, m.QueryParams...)
+	}
+	return m
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/joakim-ribier/gttp/core"
+	"github.com/joakim-ribier/gttp/models/types"
+)
+
+// Test 'ParseQueryParams' method
+func TestParseQueryParams(t *testing.T) {
+	expected := []QueryParam{{"page", "1", true}, {"q", "{query}", true}, {"debug", "", true}, {"tag", "a=b", true}}
+	if actual := ParseQueryParams("http://{host}/users?page=1&q={query}&debug&&tag=a=b#top"); !reflect.DeepEqual(actual, expected) {
+		t.Error("Expected ", expected, ", got ", actual)
+	}
+	if actual := ParseQueryParams("http://localhost/users"); actual != nil {
+		t.Error("Expected nil, got ", actual)
+	}
+}
+
+// Test 'SetURL' method (the disabled params are kept)
+func TestSetURL(t *testing.T) {
+	mrd := EmptyMakeRequestData()
+	mrd.QueryParams = []QueryParam{{"page", "1", true}, {"debug", "true", false}}
+
+	mrd = mrd.SetURL("http://localhost/users?page=2&limit=10")
+
+	expected := []QueryParam{{"page", "2", true}, {"limit", "10", true}, {"debug", "true", false}}
+	if !reflect.DeepEqual(mrd.QueryParams, expected) {
+		t.Error("Expected ", expected, ", got ", mrd.QueryParams)
+	}
+}
+
+// Test 'SetQueryParams' method (only the enabled params are in the URL)
+func TestSetQueryParams(t *testing.T) {
+	mrd := EmptyMakeRequestData()
+	mrd.URL = "http://localhost/users?old=1#top"
+
+	params := []QueryParam{{"page", "2", true}, {"debug", "true", false}, {"q", "{query}", true}}
+	mrd = mrd.SetQueryParams(params)
+
+	if expected := types.URL("http://localhost/users?page=2&q={query}#top"); mrd.URL != expected {
+		t.Error("Expected ", expected, ", got ", mrd.URL)
+	}
+	if !reflect.DeepEqual(mrd.QueryParams, params) {
+		t.Error("Expected ", params, ", got ", mrd.QueryParams)
+	}
+
+	mrd = mrd.SetQueryParams([]QueryParam{{"page", "2", false}})
+	if expected := types.URL("http://localhost/users#top"); mrd.URL != expected {
+		t.Error("Expected ", expected, ", got ", mrd.URL)
+	}
+}
+
+// Test 'Normalized' method (the legacy "{param}" headers are moved to the path params)
+func TestNormalized(t *testing.T) {
+	mrd := NewMakeRequestData("GET", "http://localhost/users/{id}?page=1", core.StringMap{"{id}": "42", "Accept": "*/*"}, "", "", "", "")
+
+	actual := mrd.Normalized()
+	if !reflect.DeepEqual(actual.MapRequestHeaderKeyValue, core.StringMap{"Accept": "*/*"}) {
+		t.Error("Expected only the Accept header, got ", actual.MapRequestHeaderKeyValue)
+	}
+	if !reflect.DeepEqual(actual.PathParams, core.StringMap{"{id}": "42"}) {
+		t.Error("Expected {id} path param, got ", actual.PathParams)
+	}
+	if expected := []QueryParam{{"page", "1", true}}; !reflect.DeepEqual(actual.QueryParams, expected) {
+		t.Error("Expected ", expected, ", got ", actual.QueryParams)
+	}
+	if _, exists := mrd.MapRequestHeaderKeyValue["{id}"]; !exists {
+		t.Error("Expected the original request unchanged")
+	}
+
+	if resolved := mrd.Resolve(nil); resolved.URL != "http://localhost/users/42?page=1" || len(resolved.Headers) != 1 {
+		t.Error("Expected resolved path param, got ", resolved.URL, resolved.Headers)
+	}
+}
+
+// Test 'PathParamName' method
+func TestPathParamName(t *testing.T) {
+	values := map[string]string{"id": "{id}", " {petId} ": "{petId}", "": ""}
+	for value, expected := range values {
+		if actual := PathParamName(value); actual != expected {
+			t.Error("Expected ", expected, ", got ", actual)
+		}
+	}
+}
+
+// Test 'References' method
+func TestResolvedRequestReferences(t *testing.T) {
+	request := ResolvedRequest{
+		URL:     "http://localhost/users",
+		Headers: core.StringMap{"X-Key": "{api_key}"},
+		Auth:    Auth{Type: AuthBearer, Token: "{Token}"},
+	}
+	for variable, expected := range map[string]bool{"{api_key}": true, "{token}": true, "{password}": false} {
+		if actual := request.References(variable); actual != expected {
+			t.Error("Expected ", expected, " for ", variable, ", got ", actual)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/joakim-ribier/gttp/core"
+)
+
+// ProxyDirect is the proxy value to connect directly (without the global proxy)
+const ProxyDirect = "direct"
+
+// ProxySchemes represents the supported proxy schemes
+var ProxySchemes = core.StringSlice{"http", "https", "socks5"}
+
+// Proxy represents the proxy settings of the requests, an empty URL means "default"
+// (the global proxy or the HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables)
+type Proxy struct {
+	URL      string
+	Username string
+	Password string
+	NoProxy  string
+}
+
+// Merge returns the proxy or the @defaults one if no proxy URL is defined
+// (the no-proxy list of the @defaults is used if it's not defined)
+func (p Proxy) Merge(defaults Proxy) Proxy {
+	if p.URL == "" {
+		return defaults
+	}
+	if p.NoProxy == "" {
+		p.NoProxy = defaults.NoProxy
+	}
+	return p
+}
+
+// IsEmpty returns true if no setting is defined
+func (p Proxy) IsEmpty() bool {
+	return p == Proxy{}
+}
+
+// Validate checks that the proxy URL is valid (http, https or socks5 scheme)
+func (p Proxy) Validate() error {
+	if p.URL == "" || p.URL == ProxyDirect {
+		return nil
+	}
+	value, error := url.Parse(p.URL)
+	if error != nil || value.Host == "" || ProxySchemes.GetIndex(value.Scheme) == -1 {
+		return errors.New("invalid proxy URL '" + p.URL + "' (ex. http://proxy:3128, socks5://proxy:1080 or " + ProxyDirect + ")")
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"testing"
+)
+
+// Test 'Merge' method
+func TestProxyMerge(t *testing.T) {
+	defaults := Proxy{URL: "http://proxy:3128", Username: "bob", Password: "secret", NoProxy: "localhost"}
+
+	if actual := (Proxy{}).Merge(defaults); actual != defaults {
+		t.Error("Expected ", defaults, ", got ", actual)
+	}
+
+	expected := Proxy{URL: "socks5://socks:1080", NoProxy: "localhost"}
+	if actual := (Proxy{URL: "socks5://socks:1080"}).Merge(defaults); actual != expected {
+		t.Error("Expected ", expected, ", got ", actual)
+	}
+
+	if actual := (Proxy{URL: ProxyDirect}).Merge(defaults); actual.URL != ProxyDirect {
+		t.Error("Expected direct, got ", actual)
+	}
+}
+
+// Test 'Validate' method
+func TestProxyValidate(t *testing.T) {
+	for _, value := range []string{"", ProxyDirect, "http://proxy:3128", "https://proxy", "socks5://127.0.0.1:1080"} {
+		if error := (Proxy{URL: value}).Validate(); error != nil {
+			t.Error("Expected nil, got ", error)
+		}
+	}
+	for _, value := range []string{"proxy:3128", "ftp://proxy", "http://"} {
+		if error := (Proxy{URL: value}).Validate(); error == nil {
+			t.Error("Expected error for ", value, ", got nil")
+		}
+	}
+}
+
+// Test 'GetProxy' & 'SetProxy' methods
+func TestContextProxy(t *testing.T) {
+	var context Context
+	context.SetProxy("Prod", Proxy{URL: "http://proxy:3128"})
+
+	if context.Copy().GetProxy("prod").URL != "http://proxy:3128" {
+		t.Error("Expected proxy for 'prod', got ", context.Proxy)
+	}
+
+	context.SetProxy("prod", Proxy{})
+	if len(context.Proxy) != 0 {
+		t.Error("Expected no proxy settings, got ", context.Proxy)
+	}
+}
@@ -10,13 +10,21 @@ import (
 
 // MakeRequestData reprensents a request structure
 type MakeRequestData struct {
-	Method                   types.Method
-	URL                      types.URL
-	MapRequestHeaderKeyValue core.StringMap
-	Body                     string
-	ContentType              string
-	ProjectName              string
-	Alias                    string
+	Method                    types.Method
+	URL                       types.URL
+	MapRequestHeaderKeyValue  core.StringMap
+	MapDisabledHeaderKeyValue core.StringMap
+	PathParams                core.StringMap
+	QueryParams               []QueryParam
+	Body                      string
+	ContentType               string
+	ProjectName               string
+	Alias                     string
+	Timeout                   Timeout
+	Assertions                []Assertion
+	Extractions               []Extraction
+	ResponseFilter            string
+	Auth                      Auth
 }
 
 // EmptyMakeRequestData creates an empty new MakeRequestData struct
@@ -48,6 +56,7 @@ func NewMakeRequestData(
 		Method:                   types.Method(method),
 		URL:                      types.URL(url),
 		MapRequestHeaderKeyValue: header,
+		PathParams:               make(core.StringMap),
 		Body:                     body,
 		ContentType:              contentType,
 		ProjectName:              projectName,
@@ -81,7 +90,7 @@ func (m MakeRequestData) TreeFormat(pattern string) string {
 	return value
 }
 
-// GetHTTPHeaderValues filters by HTTP request header params
+// GetHTTPHeaderValues returns the enabled HTTP request headers (without the legacy "{param}" keys, see Normalized)
 func (m MakeRequestData) GetHTTPHeaderValues() core.StringMap {
 	new := make(core.StringMap)
 	for key, value := range m.MapRequestHeaderKeyValue {
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+
+	"github.com/joakim-ribier/gttp/core"
+	"github.com/joakim-ribier/gttp/models/types"
+)
+
+// ResolvedRequest represents a request ready to be executed (all {param} and context variables are replaced)
+type ResolvedRequest struct {
+	Method      types.Method
+	URL         types.URL
+	ContentType string
+	Headers     core.StringMap
+	Body        string
+	Timeout     Timeout
+	TLS         TLS
+	Proxy       Proxy
+	Auth        Auth
+	OAuth2      OAuth2
+	Assertions  []Assertion
+	Extractions []Extraction
+}
+
+// Resolve replaces the {param} url and the context variables (@contextValues) of the request
+func (m MakeRequestData) Resolve(contextValues map[string]string) ResolvedRequest {
+	m = m.Normalized()
+
+	var assertions []Assertion
+	for _, assertion := range m.Assertions {
+		assertions = append(assertions, assertion.ReplaceContext(contextValues))
+	}
+
+	return ResolvedRequest{
+		Method:      m.Method,
+		URL:         m.URL.ReplaceContext(m.PathParams).ReplaceContext(contextValues),
+		ContentType: m.ContentType,
+		Headers:     m.GetHTTPHeaderValues().ReplaceContext(contextValues),
+		Body:        m.Body,
+		Timeout:     m.Timeout,
+		Auth:        m.Auth.ReplaceContext(contextValues),
+		Assertions:  assertions,
+		Extractions: m.Extractions,
+	}
+}
+
+// WithAuthHeaders returns the request with its Basic, Bearer token or API key authentication applied
+// to the headers (or to the URL), the Digest one needs the challenge of the server and is kept as is
+func (r ResolvedRequest) WithAuthHeaders() ResolvedRequest {
+	headers := make(core.StringMap)
+	for key, value := range r.Headers {
+		headers[key] = value
+	}
+
+	switch r.Auth.Type {
+	case AuthBasic:
+		headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(r.Auth.Username+":"+r.Auth.Password))
+	case AuthBearer:
+		headers["Authorization"] = "Bearer " + r.Auth.Token
+	case AuthAPIKeyHeader:
+		headers[r.Auth.Key] = r.Auth.Token
+	case AuthAPIKeyQuery:
+		base, query, fragment := splitURL(r.URL.String())
+		if query != "" {
+			query += "&"
+		}
+		query += url.QueryEscape(r.Auth.Key) + "=" + url.QueryEscape(r.Auth.Token)
+		r.URL = types.URL(base + "?" + query + fragment)
+	default:
+		return r
+	}
+
+	r.Headers = headers
+	r.Auth = Auth{}
+	return r
+}
+
+// References returns true if the @variable ("{token}") is still in the request (not replaced by a value)
+func (r ResolvedRequest) References(variable string) bool {
+	values := []string{
+		r.URL.String(), r.Body,
+		r.Auth.Username, r.Auth.Password, r.Auth.Token, r.Auth.Key,
+		r.OAuth2.TokenURL, r.OAuth2.ClientID, r.OAuth2.ClientSecret, r.OAuth2.Scopes, r.OAuth2.Username, r.OAuth2.Password,
+	}
+	for key, value := range r.Headers {
+		values = append(values, key, value)
+	}
+	for _, value := range values {
+		if strings.Contains(strings.ToLower(value), variable) {
+			return true
+		}
+	}
+	return false
+}
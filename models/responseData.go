@@ -0,0 +1,14 @@
+package models
+
+import (
+	"net/http"
+	"time"
+)
+
+// ResponseData contains the response data used to evaluate the assertions and the extraction rules
+type ResponseData struct {
+	StatusCode int
+	Headers    http.Header
+	Body       []byte
+	Duration   time.Duration
+}
@@ -0,0 +1,205 @@
+package models
+
+import (
+	"encoding/xml"
+	"strconv"
+	"time"
+)
+
+// Runner contains the results of a project executed by the collection runner
+type Runner struct {
+	ProjectName string
+	Context     string
+	Date        time.Time
+	Results     []RunnerResult
+	Variables   Context
+}
+
+// RunnerResult represents a request executed (or skipped) by the collection runner
+type RunnerResult struct {
+	Request     MakeRequestData
+	URL         string
+	Status      string
+	StatusCode  int
+	Duration    time.Duration
+	Error       string
+	Skipped     bool
+	Assertions  []AssertionResult
+	Extractions []ExtractionResult
+}
+
+// NewRunner creates a new Runner struct, all the @requests are skipped until they are executed
+func NewRunner(projectName string, context string, requests []MakeRequestData) Runner {
+	runner := Runner{
+		ProjectName: projectName,
+		Context:     context,
+		Date:        time.Now(),
+	}
+	for _, request := range requests {
+		runner.Results = append(runner.Results, RunnerResult{Request: request, Skipped: true})
+	}
+	return runner
+}
+
+// Failure returns the failure message of the result ("" if the request passed),
+// the status is checked only if the request has no assertion
+func (result RunnerResult) Failure() string {
+	switch {
+	case result.Skipped:
+		return ""
+	case result.Error != "":
+		return result.Error
+	case len(result.Assertions) > 0:
+		for _, assertion := range result.Assertions {
+			if !assertion.Passed {
+				return strconv.Itoa(FailedAssertions(result.Assertions)) + " assertion(s) failed: " +
+					assertion.Assertion.String() + " (" + assertion.Message + ")"
+			}
+		}
+		return ""
+	case result.StatusCode < 200 || result.StatusCode >= 400:
+		return "unexpected status " + result.Status
+	default:
+		return ""
+	}
+}
+
+// Passed returns true if the request has been executed without failure
+func (result RunnerResult) Passed() bool {
+	return !result.Skipped && result.Failure() == ""
+}
+
+// Name returns the name of the result (method + alias or url)
+func (result RunnerResult) Name() string {
+	label := result.Request.Alias
+	if label == "" {
+		label = result.Request.URL.String()
+	}
+	return result.Request.Method.String() + " " + label
+}
+
+// Extracted returns true if at least one value has been extracted into the context variables
+func (runner Runner) Extracted() bool {
+	for _, result := range runner.Results {
+		for _, extraction := range result.Extractions {
+			if extraction.Error == "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MergeExtracted adds the values extracted during the run to the runner env of the @context,
+// the other variables of the @context are kept as is
+func (runner Runner) MergeExtracted(context *Context) {
+	for _, result := range runner.Results {
+		for _, extraction := range result.Extractions {
+			if extraction.Error == "" {
+				context.Add(runner.Context, extraction.Extraction.Variable, extraction.Value)
+			}
+		}
+	}
+}
+
+// Count returns the number of passed, failed and skipped requests
+func (runner Runner) Count() (int, int, int) {
+	passed, failed, skipped := 0, 0, 0
+	for _, result := range runner.Results {
+		switch {
+		case result.Skipped:
+			skipped++
+		case result.Passed():
+			passed++
+		default:
+			failed++
+		}
+	}
+	return passed, failed, skipped
+}
+
+// Duration returns the total duration of the executed requests
+func (runner Runner) Duration() time.Duration {
+	var duration time.Duration
+	for _, result := range runner.Results {
+		duration += result.Duration
+	}
+	return duration
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ToJUnitXML serializes the runner results to a JUnit XML report
+func (runner Runner) ToJUnitXML() ([]byte, error) {
+	seconds := func(duration time.Duration) string {
+		return strconv.FormatFloat(duration.Seconds(), 'f', 3, 64)
+	}
+
+	projectName := runner.ProjectName
+	if projectName == "" {
+		projectName = "."
+	}
+
+	suite := junitTestSuite{
+		Name:      projectName + " (" + runner.Context + ")",
+		Tests:     len(runner.Results),
+		Time:      seconds(runner.Duration()),
+		Timestamp: runner.Date.Format("2006-01-02T15:04:05"),
+	}
+
+	for _, result := range runner.Results {
+		testCase := junitTestCase{
+			Name:      result.Name(),
+			ClassName: projectName,
+			Time:      seconds(result.Duration),
+			SystemOut: result.URL,
+		}
+		switch {
+		case result.Skipped:
+			suite.Skipped++
+			testCase.Skipped = &junitMessage{Message: "not executed"}
+		case result.Error != "":
+			suite.Errors++
+			testCase.Error = &junitMessage{Message: result.Error}
+		case !result.Passed():
+			suite.Failures++
+			testCase.Failure = &junitMessage{Message: result.Failure(), Text: result.Status}
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	data, error := xml.MarshalIndent(junitTestSuites{TestSuites: []junitTestSuite{suite}}, "", "  ")
+	if error != nil {
+		return nil, error
+	}
+	return append([]byte(xml.Header), data...), nil
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunnerResultFailure(t *testing.T) {
+	if actual := (RunnerResult{StatusCode: 200, Status: "200 OK"}).Failure(); actual != "" {
+		t.Error("Expected '', got ", actual)
+	}
+	if actual := (RunnerResult{StatusCode: 404, Status: "404 Not Found"}).Failure(); actual != "unexpected status 404 Not Found" {
+		t.Error("Expected 'unexpected status 404 Not Found', got ", actual)
+	}
+	if actual := (RunnerResult{Error: "connection refused"}).Failure(); actual != "connection refused" {
+		t.Error("Expected 'connection refused', got ", actual)
+	}
+	if actual := (RunnerResult{Skipped: true}).Passed(); actual {
+		t.Error("Expected false, got ", actual)
+	}
+}
+
+func TestRunnerCount(t *testing.T) {
+	runner := NewRunner("Jira", "default", []MakeRequestData{
+		SimpleMakeRequestData("GET", "https://jira/a", "Jira", "A"),
+		SimpleMakeRequestData("GET", "https://jira/b", "Jira", "B"),
+		SimpleMakeRequestData("GET", "https://jira/c", "Jira", "C"),
+	})
+	runner.Results[0].Skipped, runner.Results[0].StatusCode = false, 200
+	runner.Results[1].Skipped, runner.Results[1].StatusCode = false, 500
+
+	if passed, failed, skipped := runner.Count(); passed != 1 || failed != 1 || skipped != 1 {
+		t.Error("Expected 1 1 1, got ", passed, failed, skipped)
+	}
+}
+
+func TestRunnerMergeExtracted(t *testing.T) {
+	runner := NewRunner("Jira", "dev", []MakeRequestData{
+		SimpleMakeRequestData("POST", "https://jira/login", "Jira", "Login"),
+		SimpleMakeRequestData("GET", "https://jira/me", "Jira", "Me"),
+	})
+	runner.Results[0].Extractions = []ExtractionResult{
+		{Extraction: NewExtraction(ExtractJSONPath, "$.token", "token"), Value: "abc"},
+		{Extraction: NewExtraction(ExtractJSONPath, "$.id", "id"), Error: "not found"},
+	}
+
+	// the context has been updated (new variable) during the run
+	context := Context{}
+	context.Add("dev", "{host}", "jira")
+	context.Add("dev", "{id}", "42")
+	runner.MergeExtracted(&context)
+
+	if actual := context.GetAllKeyValue("dev"); actual["{host}"] != "jira" || actual["{token}"] != "abc" || actual["{id}"] != "42" {
+		t.Error("Expected host, token & id kept, got ", actual)
+	}
+}
+
+func TestRunnerToJUnitXML(t *testing.T) {
+	runner := NewRunner("Jira", "prod", []MakeRequestData{
+		SimpleMakeRequestData("GET", "https://jira/tickets", "Jira", "List tickets"),
+		SimpleMakeRequestData("POST", "https://jira/tickets", "Jira", ""),
+		SimpleMakeRequestData("DELETE", "https://jira/tickets", "Jira", "Delete <all>"),
+	})
+	runner.Results[0] = RunnerResult{Request: runner.Results[0].Request, StatusCode: 200, Status: "200 OK", Duration: 1500 * time.Millisecond}
+	runner.Results[1] = RunnerResult{Request: runner.Results[1].Request, StatusCode: 400, Status: "400 Bad Request"}
+
+	data, error := runner.ToJUnitXML()
+	if error != nil {
+		t.Fatal(error)
+	}
+	xml := string(data)
+
+	expected := []string{
+		`<testsuite name="Jira (prod)" tests="3" failures="1" errors="0" skipped="1" time="1.500"`,
+		`<testcase name="GET List tickets" classname="Jira" time="1.500">`,
+		`<failure message="unexpected status 400 Bad Request">400 Bad Request</failure>`,
+		`<testcase name="POST https://jira/tickets"`,
+		`<testcase name="DELETE Delete &lt;all&gt;"`,
+		`<skipped message="not executed"></skipped>`,
+	}
+	for _, value := range expected {
+		if !strings.Contains(xml, value) {
+			t.Error("Expected ", value, ", got ", xml)
+		}
+	}
+}
+
+func TestRunnerResultFailureWithAssertions(t *testing.T) {
+	assertions := EvaluateAssertions([]Assertion{
+		NewAssertion(AssertStatusEquals, "", "404"),
+	}, ResponseData{StatusCode: 404})
+
+	result := RunnerResult{StatusCode: 404, Status: "404 Not Found", Assertions: assertions}
+	if actual := result.Failure(); actual != "" {
+		t.Error("Expected '', got ", actual)
+	}
+
+	result.Assertions = append(result.Assertions, NewAssertion(AssertBodyContains, "", "ok").Evaluate(ResponseData{}))
+	if actual := result.Failure(); actual != "1 assertion(s) failed: Body contains 'ok' (text not found)" {
+		t.Error("Expected '1 assertion(s) failed: Body contains 'ok' (text not found)', got ", actual)
+	}
+}
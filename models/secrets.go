@@ -0,0 +1,69 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// SecretMask is displayed instead of the secret values
+const SecretMask = "••••"
+
+// secretMaskMinLength is the minimum length of a secret value to be masked,
+// a shorter value would mask any occurrence of its characters in the text (ex. "1" in a port)
+const secretMaskMinLength = 4
+
+// Secrets contains the values of the secret variables by environment (stored encrypted outside the data file)
+type Secrets map[string]map[string]string
+
+// Get returns the secret values of an environment
+func (s Secrets) Get(env string) map[string]string {
+	values := make(map[string]string)
+	for variable, value := range s[strings.ToLower(env)] {
+		values[variable] = value
+	}
+	return values
+}
+
+// Set sets the secret @value of a variable
+func (s Secrets) Set(env string, variable string, value string) {
+	env = strings.ToLower(env)
+	if s[env] == nil {
+		s[env] = make(map[string]string)
+	}
+	s[env][strings.ToLower(variable)] = value
+}
+
+// Remove removes the secret value of a variable
+func (s Secrets) Remove(env string, variable string) {
+	env = strings.ToLower(env)
+	delete(s[env], strings.ToLower(variable))
+	if len(s[env]) == 0 {
+		delete(s, env)
+	}
+}
+
+// Copy returns a deep copy of the secrets
+func (s Secrets) Copy() Secrets {
+	new := make(Secrets)
+	for env := range s {
+		new[env] = s.Get(env)
+	}
+	return new
+}
+
+// Mask replaces the secret values of the @env in the @value by SecretMask
+func (s Secrets) Mask(env string, value string) string {
+	var secrets []string
+	for _, secret := range s[strings.ToLower(env)] {
+		if len(secret) >= secretMaskMinLength {
+			secrets = append(secrets, secret)
+		}
+	}
+	// the longest first, a secret can contain another one
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+
+	for _, secret := range secrets {
+		value = strings.Replace(value, secret, SecretMask, -1)
+	}
+	return value
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"testing"
+)
+
+// Test 'Set', 'Get' & 'Remove' methods
+func TestSecrets(t *testing.T) {
+	secrets := make(Secrets)
+	secrets.Set("DEV", "{Token}", "abc")
+	secrets.Set("dev", "{password}", "secret")
+
+	values := secrets.Get("dev")
+	if len(values) != 2 || values["{token}"] != "abc" || values["{password}"] != "secret" {
+		t.Error("Expected 2 secrets, got ", values)
+	}
+
+	values["{token}"] = "updated"
+	if actual := secrets.Get("dev")["{token}"]; actual != "abc" {
+		t.Error("Expected abc, got ", actual)
+	}
+
+	secrets.Remove("dev", "{token}")
+	secrets.Remove("dev", "{password}")
+	if len(secrets) != 0 {
+		t.Error("Expected empty secrets, got ", secrets)
+	}
+}
+
+// Test 'Mask' method
+func TestSecretsMask(t *testing.T) {
+	secrets := Secrets{"dev": {"{token}": "abcd", "{key}": "abcdefgh"}, "prod": {"{token}": "abcdef", "{pin}": "1", "{empty}": ""}}
+
+	expected := "Bearer •••• & ••••"
+	if actual := secrets.Mask("dev", "Bearer abcdefgh & abcd"); actual != expected {
+		t.Error("Expected ", expected, ", got ", actual)
+	}
+
+	// only the secrets of the env, the short values are not masked
+	expected = "http://localhost:8081/?token=••••&key=abcd"
+	if actual := secrets.Mask("PROD", "http://localhost:8081/?token=abcdef&key=abcd"); actual != expected {
+		t.Error("Expected ", expected, ", got ", actual)
+	}
+}
+
+// Test 'AddSecret' & 'GetAllKeyValue' methods
+func TestContextSecretVariable(t *testing.T) {
+	context := Context{}
+	context.Add("dev", "{host}", "localhost")
+	context.AddSecret("dev", "{Token}")
+
+	if variable := context.FindVariableByEnv("dev", "{token}"); !variable.Secret || variable.Value != "" {
+		t.Error("Expected secret variable, got ", variable)
+	}
+	if values := context.GetAllKeyValue("dev"); len(values) != 1 || values["{host}"] != "localhost" {
+		t.Error("Expected only {host}, got ", values)
+	}
+
+	context.Add("dev", "{token}", "abc")
+	if variable := context.FindVariableByEnv("dev", "{token}"); variable.Secret {
+		t.Error("Expected plain variable, got ", variable)
+	}
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Represents the default timeouts if nothing is defined
+const (
+	DefaultConnectTimeout = "5s"
+	DefaultTotalTimeout   = "30s"
+)
+
+// Timeout represents the request timeouts ("500ms", "5s", "1m"...), an empty value means "default"
+type Timeout struct {
+	Connect string
+	Total   string
+}
+
+// Merge returns the timeouts completed by the @defaults values
+func (t Timeout) Merge(defaults Timeout) Timeout {
+	if t.Connect == "" {
+		t.Connect = defaults.Connect
+	}
+	if t.Total == "" {
+		t.Total = defaults.Total
+	}
+	return t
+}
+
+// Validate checks that each defined timeout is a valid positive duration
+func (t Timeout) Validate() error {
+	for _, value := range []string{t.Connect, t.Total} {
+		if value == "" {
+			continue
+		}
+		if duration, error := time.ParseDuration(value); error != nil || duration <= 0 {
+			return errors.New("invalid timeout '" + value + "' (ex. 500ms, 5s, 1m)")
+		}
+	}
+	return nil
+}
+
+// ConnectDuration returns the connect timeout (or the default one)
+func (t Timeout) ConnectDuration() time.Duration {
+	return parseDuration(t.Connect, DefaultConnectTimeout)
+}
+
+// TotalDuration returns the total timeout (or the default one)
+func (t Timeout) TotalDuration() time.Duration {
+	return parseDuration(t.Total, DefaultTotalTimeout)
+}
+
+func parseDuration(value string, defaultValue string) time.Duration {
+	if duration, error := time.ParseDuration(value); error == nil && duration > 0 {
+		return duration
+	}
+	duration, _ := time.ParseDuration(defaultValue)
+	return duration
+}
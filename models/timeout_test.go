@@ -0,0 +1,40 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+// Test 'Merge' method
+func TestTimeoutMerge(t *testing.T) {
+	actual := Timeout{Total: "1m"}.Merge(Timeout{Connect: "2s", Total: "10s"})
+
+	if actual != (Timeout{Connect: "2s", Total: "1m"}) {
+		t.Error("Expected {2s 1m}, got ", actual)
+	}
+}
+
+// Test 'Validate' method
+func TestTimeoutValidate(t *testing.T) {
+	if error := (Timeout{Connect: "500ms", Total: ""}).Validate(); error != nil {
+		t.Error("Expected nil, got ", error)
+	}
+	if error := (Timeout{Connect: "5", Total: ""}).Validate(); error == nil {
+		t.Error("Expected error, got nil")
+	}
+	if error := (Timeout{Connect: "", Total: "-1s"}).Validate(); error == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+// Test 'ConnectDuration' & 'TotalDuration' methods
+func TestTimeoutDurationDefaultValues(t *testing.T) {
+	var timeout Timeout
+
+	if actual := timeout.ConnectDuration(); actual != 5*time.Second {
+		t.Error("Expected 5s, got ", actual)
+	}
+	if actual := timeout.TotalDuration(); actual != 30*time.Second {
+		t.Error("Expected 30s, got ", actual)
+	}
+}
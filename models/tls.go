@@ -0,0 +1,52 @@
+package models
+
+import (
+	"errors"
+
+	"github.com/joakim-ribier/gttp/core"
+)
+
+// TLSVersions represents the supported minimum TLS versions ("" means default)
+var TLSVersions = core.StringSlice{"", "1.0", "1.1", "1.2", "1.3"}
+
+// TLS represents the TLS settings (PEM files) of the requests, an empty value means "default"
+type TLS struct {
+	CAFile             string
+	CertFile           string
+	KeyFile            string
+	MinVersion         string
+	InsecureSkipVerify bool
+}
+
+// Merge returns the settings completed by the @defaults values
+// (the verification is skipped if one of them skips it)
+func (t TLS) Merge(defaults TLS) TLS {
+	if t.CAFile == "" {
+		t.CAFile = defaults.CAFile
+	}
+	if t.CertFile == "" && t.KeyFile == "" {
+		t.CertFile = defaults.CertFile
+		t.KeyFile = defaults.KeyFile
+	}
+	if t.MinVersion == "" {
+		t.MinVersion = defaults.MinVersion
+	}
+	t.InsecureSkipVerify = t.InsecureSkipVerify || defaults.InsecureSkipVerify
+	return t
+}
+
+// IsEmpty returns true if no setting is defined
+func (t TLS) IsEmpty() bool {
+	return t == TLS{}
+}
+
+// Validate checks the minimum version and that the client certificate & key are defined together
+func (t TLS) Validate() error {
+	if TLSVersions.GetIndex(t.MinVersion) == -1 {
+		return errors.New("invalid TLS version '" + t.MinVersion + "' (1.0, 1.1, 1.2 or 1.3)")
+	}
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return errors.New("the client certificate and its key must be defined together")
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"testing"
+)
+
+// Test 'Merge' method
+func TestTLSMerge(t *testing.T) {
+	defaults := TLS{CAFile: "ca.pem", CertFile: "global.crt", KeyFile: "global.key", MinVersion: "1.2", InsecureSkipVerify: true}
+
+	actual := TLS{CertFile: "dev.crt", KeyFile: "dev.key", MinVersion: "1.3"}.Merge(defaults)
+
+	expected := TLS{CAFile: "ca.pem", CertFile: "dev.crt", KeyFile: "dev.key", MinVersion: "1.3", InsecureSkipVerify: true}
+	if actual != expected {
+		t.Error("Expected ", expected, ", got ", actual)
+	}
+	if actual := (TLS{}).Merge(TLS{}); !actual.IsEmpty() {
+		t.Error("Expected empty, got ", actual)
+	}
+}
+
+// Test 'Validate' method
+func TestTLSValidate(t *testing.T) {
+	if error := (TLS{MinVersion: "1.2", CertFile: "a.crt", KeyFile: "a.key"}).Validate(); error != nil {
+		t.Error("Expected nil, got ", error)
+	}
+	if error := (TLS{MinVersion: "2.0"}).Validate(); error == nil {
+		t.Error("Expected error, got nil")
+	}
+	if error := (TLS{CertFile: "a.crt"}).Validate(); error == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+// Test 'GetTLS' & 'SetTLS' methods
+func TestContextTLS(t *testing.T) {
+	var context Context
+	context.SetTLS("Dev", TLS{InsecureSkipVerify: true})
+
+	if !context.GetTLS("dev").InsecureSkipVerify || !context.Copy().GetTLS("DEV").InsecureSkipVerify {
+		t.Error("Expected insecure TLS for 'dev', got ", context.TLS)
+	}
+
+	context.SetTLS("dev", TLS{})
+	if len(context.TLS) != 0 {
+		t.Error("Expected no TLS settings, got ", context.TLS)
+	}
+}
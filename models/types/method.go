@@ -1,6 +1,7 @@
 package types
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/joakim-ribier/gttp/utils"
@@ -9,15 +10,50 @@ import (
 // Method string type value
 type Method string
 
+// tokenChars represents the special characters allowed in a HTTP method (RFC 7230 "token")
+const tokenChars = "!#$%&'*+-.^_`|~"
+
 // String returns string value
 func (m Method) String() string {
 	return string(m)
 }
 
+// IsStandard returns true if the method is one of the standard HTTP methods
+func (m Method) IsStandard() bool {
+	return utils.MethodValues.GetIndex(m.String()) != -1
+}
+
+// Validate checks that the method is a valid HTTP method (a non-empty RFC 7230 token)
+func (m Method) Validate() error {
+	if m.String() == "" {
+		return errors.New("empty method")
+	}
+	for _, c := range m.String() {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune(tokenChars, c)) {
+			return errors.New("invalid character '" + string(c) + "' in method '" + m.String() + "'")
+		}
+	}
+	return nil
+}
+
+// LabelWidth returns the width used to align the method labels
+func (m Method) LabelWidth() int {
+	width := 0
+	for _, value := range utils.MethodValues {
+		if len(value) > width {
+			width = len(value)
+		}
+	}
+	if len(m.String()) > width {
+		return len(m.String())
+	}
+	return width
+}
+
 // Label returns string to display
 func (m Method) Label() string {
 	str := m.String()
-	return str + strings.Repeat(" ", len("DELETE")-len(str))
+	return str + strings.Repeat(" ", m.LabelWidth()-len(str))
 }
 
 // TreeColor returns foreground & background
@@ -29,9 +65,19 @@ func (m Method) TreeColor() string {
 		return "[" + utils.GreenColorName + ":]"
 	case "PUT":
 		return "[orange:]"
+	case "PATCH":
+		return "[yellow:]"
 	case "DELETE":
 		return "[red:]"
-	default:
+	case "HEAD":
+		return "[mediumpurple:]"
+	case "OPTIONS":
+		return "[teal:]"
+	case "TRACE", "CONNECT":
+		return "[gray:]"
+	case "":
 		return ""
+	default:
+		return "[white:]"
 	}
 }
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"testing"
+)
+
+// Test 'Validate' method
+func TestValidate(t *testing.T) {
+	for _, value := range []string{"GET", "PATCH", "PROPFIND", "M-SEARCH"} {
+		if error := Method(value).Validate(); error != nil {
+			t.Error("Expected nil, got ", error)
+		}
+	}
+}
+
+func TestValidateInvalidMethod(t *testing.T) {
+	for _, value := range []string{"", "GET ME", "GET\n", "{GET}"} {
+		if error := Method(value).Validate(); error == nil {
+			t.Error("Expected error, got nil for ", value)
+		}
+	}
+}
+
+// Test 'IsStandard' method
+func TestIsStandard(t *testing.T) {
+	if !Method("DELETE").IsStandard() {
+		t.Error("Expected true, got false")
+	}
+	if Method("PROPFIND").IsStandard() {
+		t.Error("Expected false, got true")
+	}
+}
+
+// Test 'Label' method
+func TestLabel(t *testing.T) {
+	if actual := Method("GET").Label(); actual != "GET    " {
+		t.Error("Expected 'GET    ', got ", "'"+actual+"'")
+	}
+	if actual := Method("PROPPATCH").Label(); actual != "PROPPATCH" {
+		t.Error("Expected 'PROPPATCH', got ", "'"+actual+"'")
+	}
+}
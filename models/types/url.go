@@ -2,7 +2,8 @@ package types
 
 import (
 	"path"
-	"strings"
+
+	"github.com/joakim-ribier/gttp/core"
 )
 
 // URL string type value
@@ -20,11 +21,5 @@ func (url URL) Base() string {
 
 // ReplaceContext replaces all context {param} in the URL
 func (url URL) ReplaceContext(mapKeysValues map[string]string) URL {
-	var newURL = url.String()
-	for key, value := range mapKeysValues {
-		if strings.HasPrefix(key, "{") && strings.HasSuffix(key, "}") {
-			newURL = strings.Replace(newURL, key, value, -1)
-		}
-	}
-	return URL(newURL)
+	return URL(core.ReplaceContext(url.String(), mapKeysValues))
 }
@@ -2,8 +2,12 @@ package services
 
 import (
 	"encoding/json"
+	"errors"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 
+	"github.com/joakim-ribier/gttp/converters"
 	"github.com/joakim-ribier/gttp/models"
 	"github.com/joakim-ribier/gttp/utils"
 )
@@ -29,6 +33,7 @@ func (s *ApplicationDataService) Load() models.Output {
 	if error := json.Unmarshal(bytes, &value); error != nil {
 		s.Log("Error to decode '"+s.Filename+"' json data file.", "error")
 	}
+	value.Normalize()
 
 	return value
 }
@@ -43,3 +48,67 @@ func (s *ApplicationDataService) Save(value models.Output) {
 		}
 	}
 }
+
+// Import reads the @filename of the @format and merges it in the configuration app file,
+// it returns the list of the unsupported items which are not imported.
+func (s *ApplicationDataService) Import(format string, filename string) ([]string, error) {
+	data, error := ioutil.ReadFile(filename)
+	if error != nil {
+		return nil, error
+	}
+
+	var imported models.Output
+	var warnings []string
+
+	switch format {
+	case utils.ImportPostmanCollection:
+		imported, warnings, error = converters.ImportPostmanCollection(data)
+	case utils.ImportPostmanEnvironment:
+		imported.Context, warnings, error = converters.ImportPostmanEnvironment(data)
+	case utils.ImportOpenAPI:
+		imported, warnings, error = converters.ImportOpenAPI(data)
+	case utils.ImportHTTPFile:
+		envFilename := filepath.Join(filepath.Dir(filename), converters.HTTPFileEnvFilename)
+		envData, _ := ioutil.ReadFile(envFilename)
+		imported, warnings, error = converters.ImportHTTPFile(data, envData)
+	default:
+		error = errors.New("format '" + format + "' not supported")
+	}
+	if error != nil {
+		return warnings, error
+	}
+
+	// reload the data from file to merge only the imported data
+	output := s.Load()
+	output.Merge(imported)
+	s.Save(output)
+
+	return warnings, nil
+}
+
+// Export writes the requests of the @projectName (or all if empty) to the @filename of the @format,
+// the environments are merged into the "http-client.env.json" file next to it.
+func (s *ApplicationDataService) Export(format string, filename string, projectName string) ([]string, error) {
+	if format != utils.ImportHTTPFile {
+		return nil, errors.New("export to '" + format + "' not supported")
+	}
+	if filename == "" {
+		return nil, errors.New("empty file name")
+	}
+
+	content, envContent, warnings := converters.ExportHTTPFile(s.Load(), projectName)
+	if error := ioutil.WriteFile(filename, []byte(content), 0644); error != nil {
+		return nil, error
+	}
+
+	envFilename := filepath.Join(filepath.Dir(filename), converters.HTTPFileEnvFilename)
+	existing, error := ioutil.ReadFile(envFilename)
+	if error != nil && !os.IsNotExist(error) {
+		return nil, error
+	}
+	envData, error := converters.MergeHTTPFileEnv(existing, []byte(envContent))
+	if error != nil {
+		return nil, errors.New("'" + envFilename + "' not updated: " + error.Error())
+	}
+	return warnings, ioutil.WriteFile(envFilename, envData, 0644)
+}
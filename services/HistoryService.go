@@ -0,0 +1,58 @@
+package services
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/joakim-ribier/gttp/models"
+	"github.com/joakim-ribier/gttp/utils"
+)
+
+type HistoryService struct {
+	Filename string
+	Log      func(string, string)
+}
+
+// NewHistoryService constructs service which loads and saves the history next to the data file (data.json => data.history.json).
+func NewHistoryService(dataFilename string, log func(string, string)) *HistoryService {
+	return &HistoryService{
+		Filename: strings.TrimSuffix(dataFilename, ".json") + ".history.json",
+		Log:      log,
+	}
+}
+
+// Load deserializes json history file to a @models.History.
+func (s *HistoryService) Load() models.History {
+	var value models.History
+
+	if _, error := os.Stat(s.Filename); os.IsNotExist(error) {
+		return value
+	}
+
+	bytes := utils.ReadFile(s.Filename, s.Log)
+	if error := json.Unmarshal(bytes, &value); error != nil {
+		s.Log("Error to decode '"+s.Filename+"' json history file.", "error")
+	}
+
+	return value
+}
+
+// Save serializes @models.History in the history file.
+func (s *HistoryService) Save(value models.History) {
+	if json, error := json.Marshal(value); error != nil {
+		s.Log("Encoding 'history' model error...", "error")
+	} else {
+		if error := ioutil.WriteFile(s.Filename, json, 0600); error != nil {
+			s.Log("Writing history to '"+s.Filename+"' file error...", "error")
+		}
+	}
+}
+
+// Add reloads the history file and adds the new @entry.
+func (s *HistoryService) Add(entry models.HistoryEntry) {
+	history := s.Load()
+	history.Add(entry)
+	s.Save(history)
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/joakim-ribier/gttp/httpclient"
+	"github.com/joakim-ribier/gttp/models"
+	"github.com/joakim-ribier/gttp/models/types"
+)
+
+// authSchemes maps the authentication types to the http client schemes
+var authSchemes = map[string]string{
+	models.AuthBasic:        httpclient.AuthBasic,
+	models.AuthBearer:       httpclient.AuthBearer,
+	models.AuthAPIKeyHeader: httpclient.AuthAPIKeyHeader,
+	models.AuthAPIKeyQuery:  httpclient.AuthAPIKeyQuery,
+	models.AuthDigest:       httpclient.AuthDigest,
+}
+
+type RequestService struct {
+	GetOutput func() models.Output
+	Secrets   *SecretService
+	Tokens    *httpclient.TokenCache
+}
+
+// NewRequestService constructs service which resolves (context, secrets, settings...) and executes the requests.
+func NewRequestService(getOutput func() models.Output, secrets *SecretService) *RequestService {
+	return &RequestService{
+		GetOutput: getOutput,
+		Secrets:   secrets,
+		Tokens:    httpclient.NewTokenCache(),
+	}
+}
+
+// Resolve replaces all variables of the @makeRequestData with the @env context values and applies the settings.
+func (s *RequestService) Resolve(makeRequestData models.MakeRequestData, env string) (models.ResolvedRequest, error) {
+	output := s.GetOutput()
+	return s.ResolveWithContext(makeRequestData, output, output.Context, env)
+}
+
+// ResolveWithContext replaces all variables of the @makeRequestData with the @env values of the @context
+// and applies the settings of the @output (snapshot taken by the caller),
+// an error is returned if the request references a secret variable without value (ex. ErrSecretsLocked).
+func (s *RequestService) ResolveWithContext(makeRequestData models.MakeRequestData, output models.Output, context models.Context, env string) (models.ResolvedRequest, error) {
+	// the secret values are resolved only here, just before the execution
+	secrets, locked := s.Secrets.Get(env)
+	contextValues := context.GetAllKeyValue(env)
+	for variable, value := range secrets {
+		contextValues[variable] = value
+	}
+
+	request := makeRequestData.Resolve(contextValues)
+	request.Timeout = makeRequestData.Timeout.Merge(output.Config.Timeout)
+	request.TLS = context.GetTLS(env).Merge(output.Config.TLS)
+	request.Proxy = context.GetProxy(env).Merge(output.Config.Proxy)
+	request.OAuth2 = context.GetOAuth2(env).ReplaceContext(contextValues)
+
+	for _, variable := range context.GetSecretVariables(env) {
+		if _, exists := secrets[variable]; exists || !request.References(variable) {
+			continue
+		}
+		if locked != nil {
+			return request, locked
+		}
+		return request, errors.New("the secret '" + variable + "' has no value")
+	}
+
+	return request, nil
+}
+
+// ResolveSnippet resolves the @makeRequestData like Resolve with its authentication applied as headers
+// (or query param) to export it as snippet, the secret values are masked.
+func (s *RequestService) ResolveSnippet(makeRequestData models.MakeRequestData, env string) models.ResolvedRequest {
+	// the snippet is exported even if a secret is not resolved (the variable is kept)
+	request, _ := s.Resolve(makeRequestData, env)
+
+	// masked before being encoded (ex. Basic)
+	request.Auth.Username = s.Mask(env, request.Auth.Username)
+	request.Auth.Password = s.Mask(env, request.Auth.Password)
+	request.Auth.Token = s.Mask(env, request.Auth.Token)
+	request = request.WithAuthHeaders()
+
+	request.URL = types.URL(s.Mask(env, request.URL.String()))
+	for key, value := range request.Headers {
+		request.Headers[key] = s.Mask(env, value)
+	}
+	request.Body = s.Mask(env, request.Body)
+	return request
+}
+
+// Call executes the resolved @request, the OAuth2 token of the execution context (if defined)
+// is fetched first & sent as a Bearer token unless the request has its own authentication.
+func (s *RequestService) Call(ctx context.Context, request models.ResolvedRequest, logger func(message string, mode string)) (*httpclient.HTTPClient, error) {
+	options := httpclient.Options{
+		Timeout: httpclient.Timeout{
+			Connect: request.Timeout.ConnectDuration(),
+			Total:   request.Timeout.TotalDuration(),
+		},
+		TLS: httpclient.TLS{
+			CAFile:             request.TLS.CAFile,
+			CertFile:           request.TLS.CertFile,
+			KeyFile:            request.TLS.KeyFile,
+			MinVersion:         request.TLS.MinVersion,
+			InsecureSkipVerify: request.TLS.InsecureSkipVerify,
+		},
+		Proxy: httpclient.Proxy{
+			URL:      request.Proxy.URL,
+			Username: request.Proxy.Username,
+			Password: request.Proxy.Password,
+			NoProxy:  request.Proxy.NoProxy,
+		},
+		Auth: httpclient.Auth{
+			Scheme:   authSchemes[request.Auth.Type],
+			Username: request.Auth.Username,
+			Password: request.Auth.Password,
+			Token:    request.Auth.Token,
+			Key:      request.Auth.Key,
+		},
+	}
+
+	var oauth2 httpclient.OAuth2
+	if request.Auth.IsEmpty() && !request.OAuth2.IsEmpty() {
+		oauth2 = httpclient.OAuth2{
+			GrantType:    request.OAuth2.GrantType,
+			TokenURL:     request.OAuth2.TokenURL,
+			ClientID:     request.OAuth2.ClientID,
+			ClientSecret: request.OAuth2.ClientSecret,
+			Scopes:       request.OAuth2.Scopes,
+			Username:     request.OAuth2.Username,
+			Password:     request.OAuth2.Password,
+		}
+		token, error := s.Tokens.Token(ctx, oauth2, options, logger)
+		if error != nil {
+			return nil, error
+		}
+		options.Auth = httpclient.Auth{Scheme: httpclient.AuthBearer, Token: token.AccessToken}
+	}
+
+	client, error := httpclient.Call(ctx, request.Method, request.URL, request.ContentType, []byte(request.Body), request.Headers, options, logger)
+	if error == nil && oauth2.TokenURL != "" && client.Response.Response.StatusCode == 401 {
+		// the token is rejected (revoked...), a new one is fetched by the next call
+		s.Tokens.Invalidate(oauth2)
+	}
+	return client, error
+}
+
+// Mask replaces the secret values of the @env in the @value (ex. before saving it in the history).
+func (s *RequestService) Mask(env string, value string) string {
+	return s.Secrets.Mask(env, value)
+}
+
+// Assert evaluates the assertions of the @request on the response of the @client.
+func (s *RequestService) Assert(request models.ResolvedRequest, client *httpclient.HTTPClient, duration time.Duration) []models.AssertionResult {
+	return models.EvaluateAssertions(request.Assertions, responseData(client, duration))
+}
+
+// Extract applies the extraction rules of the @request on the response of the @client,
+// the extracted values are added to the @env of the @context.
+func (s *RequestService) Extract(request models.ResolvedRequest, client *httpclient.HTTPClient, duration time.Duration, context *models.Context, env string) []models.ExtractionResult {
+	return models.ApplyExtractions(request.Extractions, responseData(client, duration), context, env)
+}
+
+func responseData(client *httpclient.HTTPClient, duration time.Duration) models.ResponseData {
+	return models.ResponseData{
+		StatusCode: client.Response.Response.StatusCode,
+		Headers:    client.Response.Response.Header,
+		Body:       client.Body,
+		Duration:   duration,
+	}
+}
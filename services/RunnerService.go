@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/joakim-ribier/gttp/httpclient"
+	"github.com/joakim-ribier/gttp/models"
+)
+
+type RunnerService struct {
+	RequestService *RequestService
+}
+
+// NewRunnerService constructs service which executes all the requests of a project in sequence.
+func NewRunnerService(requestService *RequestService) *RunnerService {
+	return &RunnerService{
+		RequestService: requestService,
+	}
+}
+
+// Requests returns the requests of the @projectName in the tree order ("." for the requests without project).
+func (s *RunnerService) Requests(projectName string) []models.MakeRequestData {
+	return requests(s.RequestService.GetOutput(), projectName)
+}
+
+func requests(output models.Output, projectName string) []models.MakeRequestData {
+	_, dataAPIsByProjectName := output.SortDataAPIsByProjectName()
+	if projectName == "" {
+		projectName = "."
+	}
+	return dataAPIsByProjectName[projectName]
+}
+
+// Run executes in sequence all the requests of the @projectName of the @output with the @env context,
+// @onResult is called after each request and the run stops on the first failure if @stopOnFailure.
+// The values extracted from a response are available for the next requests (see runner.Variables).
+// The @output is a snapshot taken by the caller, the run can be executed in background.
+func (s *RunnerService) Run(
+	ctx context.Context,
+	output models.Output,
+	projectName string,
+	env string,
+	stopOnFailure bool,
+	onResult func(index int, result models.RunnerResult)) models.Runner {
+
+	runner := models.NewRunner(projectName, env, requests(output, projectName))
+	runner.Variables = output.Context.Copy()
+
+	for index, value := range runner.Results {
+		if ctx.Err() != nil {
+			break
+		}
+
+		request, error := s.RequestService.ResolveWithContext(value.Request, output, runner.Variables, env)
+		result := models.RunnerResult{Request: value.Request, URL: s.RequestService.Mask(env, request.URL.String())}
+
+		var HTTPClient *httpclient.HTTPClient
+		if error == nil {
+			start := time.Now()
+			HTTPClient, error = s.RequestService.Call(ctx, request, func(message string, mode string) {})
+			result.Duration = time.Since(start)
+		}
+
+		if errors.Is(error, context.Canceled) {
+			// stopped by the user, the request stays skipped
+			break
+		} else if error != nil {
+			result.Error = fmt.Sprint(error)
+		} else {
+			result.Status = HTTPClient.Response.Status
+			result.StatusCode = HTTPClient.Response.Response.StatusCode
+			result.Assertions = s.RequestService.Assert(request, HTTPClient, result.Duration)
+			result.Extractions = s.RequestService.Extract(request, HTTPClient, result.Duration, &runner.Variables, env)
+		}
+
+		runner.Results[index] = result
+		if onResult != nil {
+			onResult(index, result)
+		}
+
+		if stopOnFailure && !result.Passed() {
+			break
+		}
+	}
+
+	return runner
+}
+
+// ExportJUnit writes the @runner results as JUnit XML in the @filename.
+func (s *RunnerService) ExportJUnit(runner models.Runner, filename string) error {
+	if filename == "" {
+		return errors.New("empty file name")
+	}
+	data, error := runner.ToJUnitXML()
+	if error != nil {
+		return error
+	}
+	return ioutil.WriteFile(filename, data, 0644)
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/joakim-ribier/gttp/core"
+	"github.com/joakim-ribier/gttp/models"
+)
+
+// ErrSecretsLocked is returned when the secrets are used before being unlocked with the passphrase
+var ErrSecretsLocked = errors.New("the secrets are locked, unlock them with the passphrase first")
+
+type SecretService struct {
+	Filename string
+	Log      func(string, string)
+
+	mutex      sync.RWMutex
+	passphrase string
+	secrets    models.Secrets
+}
+
+// NewSecretService constructs service which loads and saves the secret values next to the data file (data.json => data.secrets),
+// the file is encrypted with a passphrase and must not be committed.
+func NewSecretService(dataFilename string, log func(string, string)) *SecretService {
+	return &SecretService{
+		Filename: strings.TrimSuffix(dataFilename, ".json") + ".secrets",
+		Log:      log,
+	}
+}
+
+// Unlock decrypts the secrets file with the @passphrase (a new file is encrypted with it).
+func (s *SecretService) Unlock(passphrase string) error {
+	if passphrase == "" {
+		return errors.New("empty passphrase")
+	}
+
+	secrets := make(models.Secrets)
+	if data, error := ioutil.ReadFile(s.Filename); error == nil {
+		value, error := core.Decrypt(data, passphrase)
+		if error != nil {
+			return error
+		}
+		if error := json.Unmarshal(value, &secrets); error != nil {
+			return errors.New("invalid secrets file '" + s.Filename + "'")
+		}
+	} else if !os.IsNotExist(error) {
+		return error
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.passphrase = passphrase
+	s.secrets = secrets
+	return nil
+}
+
+// Get returns the secret values of the @env (ErrSecretsLocked if locked).
+func (s *SecretService) Get(env string) (map[string]string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.secrets == nil {
+		return map[string]string{}, ErrSecretsLocked
+	}
+	return s.secrets.Get(env), nil
+}
+
+// Set sets the secret @value of the @variable and saves the encrypted secrets file.
+func (s *SecretService) Set(env string, variable string, value string) error {
+	return s.update(func(secrets models.Secrets) {
+		secrets.Set(env, variable, value)
+	})
+}
+
+// Remove removes the secret value of the @variable and saves the encrypted secrets file.
+func (s *SecretService) Remove(env string, variable string) error {
+	return s.update(func(secrets models.Secrets) {
+		secrets.Remove(env, variable)
+	})
+}
+
+// Mask replaces the secret values of the @env in the @value by models.SecretMask.
+func (s *SecretService) Mask(env string, value string) string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.secrets.Mask(env, value)
+}
+
+func (s *SecretService) update(apply func(secrets models.Secrets)) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.secrets == nil {
+		return ErrSecretsLocked
+	}
+	secrets := s.secrets.Copy()
+	apply(secrets)
+
+	value, error := json.Marshal(secrets)
+	if error != nil {
+		return error
+	}
+	data, error := core.Encrypt(value, s.passphrase)
+	if error != nil {
+		return error
+	}
+	if error := ioutil.WriteFile(s.Filename, data, 0600); error != nil {
+		s.Log("Writing secrets to '"+s.Filename+"' file error...", "error")
+		return error
+	}
+
+	s.secrets = secrets
+	return nil
+}
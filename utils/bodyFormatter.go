@@ -0,0 +1,308 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/joakim-ribier/gttp/core"
+	"github.com/rivo/tview"
+)
+
+// Represents the body formats which can be pretty-printed
+const (
+	BodyFormatJSON = "json"
+	BodyFormatXML  = "xml"
+	BodyFormatHTML = "html"
+)
+
+// Represents the colors of the pretty-printed body
+const (
+	prettyKeyColor     = BlueColorName
+	prettyStringColor  = GreenColorName
+	prettyNumberColor  = "orange"
+	prettyLiteralColor = "mediumpurple"
+	prettyCommentColor = "gray"
+	prettyDefaultColor = "white"
+)
+
+var (
+	markupAttributeRegex = regexp.MustCompile(`([^\s=]+)(\s*=\s*)("[^"]*"|'[^']*'|[^\s"'>]+)`)
+
+	htmlVoidElements = core.StringSlice{"area", "base", "br", "col", "embed", "hr", "img", "input", "link", "meta", "param", "source", "track", "wbr"}
+	htmlRawElements  = core.StringSlice{"script", "style", "pre", "textarea"}
+)
+
+// DetectBodyFormat detects the format of the @body from the @contentType (or from the body content if the content type is unknown)
+func DetectBodyFormat(contentType string, body string) string {
+	contentType = strings.ToLower(contentType)
+	switch {
+	case strings.Contains(contentType, "json"):
+		return BodyFormatJSON
+	case strings.Contains(contentType, "html"):
+		return BodyFormatHTML
+	case strings.Contains(contentType, "xml"):
+		return BodyFormatXML
+	}
+
+	value := strings.TrimSpace(body)
+	switch {
+	case (strings.HasPrefix(value, "{") || strings.HasPrefix(value, "[")) && json.Valid([]byte(value)):
+		return BodyFormatJSON
+	case strings.HasPrefix(strings.ToLower(value), "<!doctype html") || strings.HasPrefix(strings.ToLower(value), "<html"):
+		return BodyFormatHTML
+	case strings.HasPrefix(value, "<"):
+		return BodyFormatXML
+	}
+	return ""
+}
+
+// FormatBody formats the @body to display (the tview tags are escaped),
+// the body is indented and highlighted if @pretty and the format is supported
+func FormatBody(contentType string, body string, pretty bool) string {
+	if pretty {
+		switch DetectBodyFormat(contentType, body) {
+		case BodyFormatJSON:
+			if value, error := PrettyJSON(body); error == nil {
+				return value
+			}
+		case BodyFormatXML:
+			return PrettyMarkup(body, false)
+		case BodyFormatHTML:
+			return PrettyMarkup(body, true)
+		}
+	}
+	return "[" + BlueColorName + "]" + tview.Escape(body)
+}
+
+// PrettyJSON indents the json @body and highlights the keys, strings, numbers and literals (true, false, null)
+func PrettyJSON(body string) (string, error) {
+	var indented bytes.Buffer
+	if error := json.Indent(&indented, []byte(strings.TrimSpace(body)), "", "  "); error != nil {
+		return "", error
+	}
+	value := indented.String()
+
+	color := func(color string, text string) string {
+		return "[" + color + "]" + tview.Escape(text)
+	}
+
+	var sb strings.Builder
+	for index := 0; index < len(value); {
+		c := value[index]
+		switch {
+		case c == '"':
+			end := index + 1
+			for end < len(value) && value[end] != '"' {
+				if value[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end++
+			token := value[index:end]
+
+			// a key is followed by ':'
+			next := strings.TrimLeft(value[end:], " ")
+			if strings.HasPrefix(next, ":") {
+				sb.WriteString(color(prettyKeyColor, token))
+			} else {
+				sb.WriteString(color(prettyStringColor, token))
+			}
+			index = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := index + 1
+			for end < len(value) && strings.IndexByte("0123456789.eE+-", value[end]) != -1 {
+				end++
+			}
+			sb.WriteString(color(prettyNumberColor, value[index:end]))
+			index = end
+		case c == 't' || c == 'f' || c == 'n':
+			end := index + 1
+			for end < len(value) && value[end] >= 'a' && value[end] <= 'z' {
+				end++
+			}
+			sb.WriteString(color(prettyLiteralColor, value[index:end]))
+			index = end
+		default:
+			end := index + 1
+			for end < len(value) && strings.IndexByte("\"-0123456789tfn", value[end]) == -1 {
+				end++
+			}
+			// "[]" (empty array) is a tview tag
+			sb.WriteString("[" + prettyDefaultColor + "]" + strings.Replace(value[index:end], "[]", "[[]]", -1))
+			index = end
+		}
+	}
+	return sb.String(), nil
+}
+
+// PrettyMarkup indents the xml (or html if @html) @body and highlights the tags, the attributes and the comments
+func PrettyMarkup(body string, html bool) string {
+	tokens := tokenizeMarkup(body, html)
+
+	var sb strings.Builder
+	depth := 0
+	indent := func() string {
+		return strings.Repeat("  ", depth)
+	}
+
+	for index := 0; index < len(tokens); index++ {
+		token := tokens[index]
+		switch token.kind {
+		case markupText:
+			sb.WriteString(indent() + "[" + prettyDefaultColor + "]" + tview.Escape(token.value) + "\r\n")
+		case markupComment:
+			sb.WriteString(indent() + "[" + prettyCommentColor + "]" + tview.Escape(token.value) + "\r\n")
+		case markupClose:
+			if depth > 0 {
+				depth--
+			}
+			sb.WriteString(indent() + colorizeTag(token.value) + "\r\n")
+		case markupOpen:
+			// <tag>text</tag> on the same line
+			if index+2 < len(tokens) && tokens[index+1].kind == markupText &&
+				tokens[index+2].kind == markupClose && tokens[index+2].name == token.name {
+				sb.WriteString(indent() + colorizeTag(token.value) +
+					"[" + prettyDefaultColor + "]" + tview.Escape(tokens[index+1].value) +
+					colorizeTag(tokens[index+2].value) + "\r\n")
+				index += 2
+				continue
+			}
+			sb.WriteString(indent() + colorizeTag(token.value) + "\r\n")
+			if !(html && htmlVoidElements.GetIndex(token.name) != -1) {
+				depth++
+			}
+		default:
+			sb.WriteString(indent() + colorizeTag(token.value) + "\r\n")
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\r\n")
+}
+
+// Represents the kinds of markup tokens
+const (
+	markupText = iota
+	markupComment
+	markupOpen
+	markupClose
+	markupSelfClosing
+)
+
+type markupToken struct {
+	kind  int
+	name  string
+	value string
+}
+
+func tokenizeMarkup(body string, html bool) []markupToken {
+	var tokens []markupToken
+
+	addText := func(text string) {
+		if text = strings.TrimSpace(text); text != "" {
+			tokens = append(tokens, markupToken{kind: markupText, value: text})
+		}
+	}
+
+	for index := 0; index < len(body); {
+		start := strings.Index(body[index:], "<")
+		if start == -1 {
+			addText(body[index:])
+			break
+		}
+		addText(body[index : index+start])
+		index += start
+
+		// comments & CDATA
+		if strings.HasPrefix(body[index:], "<!--") || strings.HasPrefix(body[index:], "<![CDATA[") {
+			closing := "-->"
+			if strings.HasPrefix(body[index:], "<![CDATA[") {
+				closing = "]]>"
+			}
+			end := strings.Index(body[index:], closing)
+			if end == -1 {
+				end = len(body) - index
+			} else {
+				end += len(closing)
+			}
+			tokens = append(tokens, markupToken{kind: markupComment, value: body[index : index+end]})
+			index += end
+			continue
+		}
+
+		// tag (the quoted attribute values can contain '>')
+		end := index + 1
+		var quote byte
+		for end < len(body) {
+			if quote != 0 {
+				if body[end] == quote {
+					quote = 0
+				}
+			} else if body[end] == '"' || body[end] == '\'' {
+				quote = body[end]
+			} else if body[end] == '>' {
+				break
+			}
+			end++
+		}
+		if end < len(body) {
+			end++
+		}
+		tag := body[index:end]
+		index = end
+
+		token := markupToken{value: tag, name: markupTagName(tag)}
+		switch {
+		case strings.HasPrefix(tag, "</"):
+			token.kind = markupClose
+		case strings.HasPrefix(tag, "<?") || strings.HasPrefix(tag, "<!") || strings.HasSuffix(tag, "/>"):
+			token.kind = markupSelfClosing
+		default:
+			token.kind = markupOpen
+		}
+		tokens = append(tokens, token)
+
+		// the content of the raw elements (ex. <script>) is not parsed
+		if html && token.kind == markupOpen && htmlRawElements.GetIndex(token.name) != -1 {
+			closing := "</" + token.name
+			end := strings.Index(strings.ToLower(body[index:]), closing)
+			if end == -1 {
+				end = len(body) - index
+			}
+			addText(body[index : index+end])
+			index += end
+		}
+	}
+	return tokens
+}
+
+func markupTagName(tag string) string {
+	name := strings.TrimLeft(tag, "</?!")
+	if end := strings.IndexAny(name, " \t\r\n/>"); end != -1 {
+		name = name[:end]
+	}
+	return strings.ToLower(name)
+}
+
+func colorizeTag(tag string) string {
+	name := strings.IndexAny(tag, " \t\r\n")
+	if name == -1 {
+		return "[" + prettyKeyColor + "]" + tview.Escape(tag)
+	}
+
+	end := len(tag) - 1
+	if strings.HasSuffix(tag, "/>") || strings.HasSuffix(tag, "?>") {
+		end = len(tag) - 2
+	}
+	if end < name {
+		end = name
+	}
+
+	attributes := markupAttributeRegex.ReplaceAllStringFunc(tview.Escape(tag[name:end]), func(attribute string) string {
+		match := markupAttributeRegex.FindStringSubmatch(attribute)
+		return "[" + prettyNumberColor + "]" + match[1] + "[" + prettyDefaultColor + "]" + match[2] + "[" + prettyStringColor + "]" + match[3]
+	})
+
+	return "[" + prettyKeyColor + "]" + tview.Escape(tag[:name]) + attributes + "[" + prettyKeyColor + "]" + tview.Escape(tag[end:])
+}
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// untag removes the tview color tags
+func untag(value string) string {
+	value = regexp.MustCompile(`\[([a-zA-Z]*|#[0-9a-fA-F]{6})\]`).ReplaceAllString(value, "")
+	return strings.Replace(value, "[[]]", "[]", -1)
+}
+
+func TestDetectBodyFormat(t *testing.T) {
+	values := map[[2]string]string{
+		{"application/json; charset=utf-8", ""}: BodyFormatJSON,
+		{"application/problem+json", ""}:        BodyFormatJSON,
+		{"text/xml", ""}:                        BodyFormatXML,
+		{"application/atom+xml", ""}:            BodyFormatXML,
+		{"text/html; charset=UTF-8", ""}:        BodyFormatHTML,
+		{"text/plain", `{"id": 1}`}:             BodyFormatJSON,
+		{"", `<!DOCTYPE html><html></html>`}:    BodyFormatHTML,
+		{"", `<?xml version="1.0"?><a></a>`}:    BodyFormatXML,
+		{"text/plain", "hello"}:                 "",
+		{"text/plain", "{not json"}:             "",
+	}
+	for value, expected := range values {
+		if actual := DetectBodyFormat(value[0], value[1]); actual != expected {
+			t.Error("Expected '", expected, "' for ", value, ", got '", actual, "'")
+		}
+	}
+}
+
+func TestPrettyJSON(t *testing.T) {
+	actual, error := PrettyJSON(`{"id":1,"name":"gttp","tags":[],"ok":true,"parent":null}`)
+	if error != nil {
+		t.Fatal(error)
+	}
+
+	expected := "{\n  \"id\": 1,\n  \"name\": \"gttp\",\n  \"tags\": [],\n  \"ok\": true,\n  \"parent\": null\n}"
+	if untag(actual) != expected {
+		t.Error("Expected ", expected, ", got ", untag(actual))
+	}
+	if !strings.Contains(actual, "["+BlueColorName+"]\"name\"") || !strings.Contains(actual, "["+GreenColorName+"]\"gttp\"") {
+		t.Error("Expected highlighted keys & strings, got ", actual)
+	}
+
+	if _, error := PrettyJSON("{"); error == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestPrettyMarkup(t *testing.T) {
+	actual := untag(PrettyMarkup(`<?xml version="1.0"?><root><!-- list --><item id="1">a</item><item id="2"/></root>`, false))
+	expected := strings.Join([]string{
+		`<?xml version="1.0"?>`,
+		`<root>`,
+		`  <!-- list -->`,
+		`  <item id="1">a</item>`,
+		`  <item id="2"/>`,
+		`</root>`,
+	}, "\r\n")
+	if actual != expected {
+		t.Error("Expected ", expected, ", got ", actual)
+	}
+
+	actual = untag(PrettyMarkup(`<html><head><meta charset="utf-8"><script>if (a < b) {}</script></head><body><p>Hi<br>there</p></body></html>`, true))
+	expected = strings.Join([]string{
+		`<html>`,
+		`  <head>`,
+		`    <meta charset="utf-8">`,
+		`    <script>if (a < b) {}</script>`,
+		`  </head>`,
+		`  <body>`,
+		`    <p>`,
+		`      Hi`,
+		`      <br>`,
+		`      there`,
+		`    </p>`,
+		`  </body>`,
+		`</html>`,
+	}, "\r\n")
+	if actual != expected {
+		t.Error("Expected ", expected, ", got ", actual)
+	}
+}
+
+func TestFormatBody(t *testing.T) {
+	if actual := FormatBody("application/json", `{"a":1}`, false); actual != "["+BlueColorName+"]"+`{"a":1}` {
+		t.Error("Expected raw body, got ", actual)
+	}
+	if actual := untag(FormatBody("application/json", "{invalid", true)); actual != "{invalid" {
+		t.Error("Expected raw body, got ", actual)
+	}
+}
@@ -48,6 +48,24 @@ const (
 	ShortcutR  = "Ctrl+[" + BlueColorName + "::ub]R[white::-] Request Header View"
 	ShortcutDC = "Ctrl+[" + BlueColorName + "::ub]C[white::-] Copy Response"
 	ShortcutDA = "Ctrl+[" + BlueColorName + "::ub]A[white::-] Copy All (log)"
+	ShortcutX  = "Ctrl+[" + BlueColorName + "::ub]X[white::-] Cancel Request"
+	ShortcutT  = "Ctrl+[" + BlueColorName + "::ub]T[white::-] History"
+	ShortcutG  = "Ctrl+[" + BlueColorName + "::ub]G[white::-] Import curl"
+	ShortcutL  = "Ctrl+[" + BlueColorName + "::ub]L[white::-] Runner"
+	ShortcutP  = "Ctrl+[" + BlueColorName + "::ub]P[white::-] Raw/Pretty"
+	ShortcutY  = "Ctrl+[" + BlueColorName + "::ub]Y[white::-] JSON Explorer"
+
+	ShortcutFilter = "[" + BlueColorName + "::ub]/[white::-] Filter"
+
+	ShortcutHistoryReopen = "[" + BlueColorName + "::ub]O[white::-]pen (Enter)"
+	ShortcutHistoryReplay = "[" + BlueColorName + "::ub]R[white::-]eplay"
+	ShortcutHistoryClear  = "Clear ([" + BlueColorName + "::ub]X[white::-])"
+
+	ShortcutResponseHeaders = "Ctrl+[" + BlueColorName + "::ub]Down[white::-] Response Headers >> Ctrl+[" + BlueColorName + "::ub]Up[white::-] Request"
+
+	ShortcutJSONTree = "[" + BlueColorName + "::ub]C[white::-]opy value | Copy JSON[" + BlueColorName + "::ub]P[white::-]ath | Context [" + BlueColorName + "::ub]V[white::-]ariable"
+
+	ShortcutRunnerResults = "Ctrl+[" + BlueColorName + "::ub]Down[white::-] Results >> Ctrl+[" + BlueColorName + "::ub]Up[white::-] Form"
 
 	ShortcutHSubMenu        = ShortcutH + " >> Ctrl+[" + BlueColorName + "::ub]Down[white::-] Left Menu >> Select Letter (or press down/up)"
 	SettingsShortcutSubMenu = SettingsShortcut + " >> Ctrl+[" + BlueColorName + "::ub]Down[white::-] Left Menu >> Select Letter (or press down/up)"
@@ -59,15 +77,37 @@ const (
 
 // Represents data shortcuts to display to the user
 var (
-	MainShortcutsText       = strings.Join([]string{SelectAPIShortcut, ShortcutF, ShortcutH, ShortcutD, SettingsShortcut, ShortcutQ}, ShortcutSeparator)
-	ResultShortcutsText     = strings.Join([]string{ShortcutR, ShortcutDC, ShortcutDA, ShortcutPressEscape}, ShortcutSeparator)
+	MainShortcutsText       = strings.Join([]string{SelectAPIShortcut, ShortcutF, ShortcutH, ShortcutD, ShortcutT, ShortcutL, ShortcutG, SettingsShortcut, ShortcutQ}, ShortcutSeparator)
+	ResultShortcutsText     = strings.Join([]string{ShortcutR, ShortcutDC, ShortcutDA, ShortcutP, ShortcutFilter, ShortcutY, ShortcutX, ShortcutPressEscape}, ShortcutSeparator)
+	RequestShortcutsText    = strings.Join([]string{ShortcutResponseHeaders, ShortcutD, ShortcutPressEscape}, ShortcutSeparator)
 	ExpertModeShortcutsText = strings.Join([]string{ShortcutHSubMenu, ShortcutPressEscape}, ShortcutSeparator)
 	SettingsShortcutsText   = strings.Join([]string{SettingsShortcutSubMenu, ShortcutPressEscape}, ShortcutSeparator)
+	HistoryShortcutsText    = strings.Join([]string{ShortcutHistoryReopen, ShortcutHistoryReplay, ShortcutHistoryClear, ShortcutPressEscape}, ShortcutSeparator)
+	JSONTreeShortcutsText   = strings.Join([]string{ShortcutJSONTree, ShortcutD, ShortcutPressEscape}, ShortcutSeparator)
+	RunnerShortcutsText     = strings.Join([]string{ShortcutRunnerResults, ShortcutPressEscape}, ShortcutSeparator)
+)
+
+// Represents the supported import formats
+const (
+	ImportPostmanCollection  = "Postman collection (v2.1)"
+	ImportPostmanEnvironment = "Postman environment"
+	ImportOpenAPI            = "OpenAPI 3 / Swagger 2 (json, yaml)"
+	ImportHTTPFile           = ".http / .rest file"
 )
 
+// ImportFormatValues lists the supported import formats
+var ImportFormatValues = []string{ImportHTTPFile, ImportPostmanCollection, ImportPostmanEnvironment, ImportOpenAPI}
+
+// ExportFormatValues lists the supported export formats
+var ExportFormatValues = []string{ImportHTTPFile}
+
+// AllProjectsValue represents the "all projects" option
+const AllProjectsValue = "All projects"
+
 // Represents data to make a new request
 var (
-	MethodValues      = core.StringSlice{"GET", "POST", "PUT"}
+	MethodValues      = core.StringSlice{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS", "TRACE", "CONNECT"}
+	CustomMethodValue = "Custom..."
 	ContentTypeValues = core.StringSlice{
 		"application/javascript",
 		"application/json",
@@ -98,3 +138,6 @@ var (
 		"image/gif",
 	}
 )
+
+// SpinnerFrames represents the frames of the "request in progress" spinner
+var SpinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
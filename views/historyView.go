@@ -0,0 +1,197 @@
+package views
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/joakim-ribier/gttp/core"
+	"github.com/joakim-ribier/gttp/models"
+	"github.com/joakim-ribier/gttp/utils"
+	"github.com/rivo/tview"
+)
+
+// HistoryView represents the history of the executed requests
+type HistoryView struct {
+	App    *tview.Application
+	AppCtx *models.AppCtx
+
+	Labels  map[string]string
+	entries []models.HistoryEntry
+
+	TablePrmt  *tview.Table
+	DetailPrmt *tview.TextView
+	ParentPrmt tview.Primitive
+
+	// Actions
+	Reopen func(entry models.HistoryEntry)
+	Replay func(entry models.HistoryEntry)
+	Clear  func()
+}
+
+// NewHistoryView returns the view for the requests history
+func NewHistoryView(
+	app *tview.Application,
+	ctx *models.AppCtx,
+	reopen func(entry models.HistoryEntry),
+	replay func(entry models.HistoryEntry),
+	clear func()) *HistoryView {
+
+	labels := make(map[string]string)
+	labels["title"] = "Requests History"
+	labels["empty"] = "No request executed yet..."
+	labels["date"] = "Date"
+	labels["context"] = "Execution Context"
+	labels["duration"] = "Duration"
+	labels["status"] = "Status"
+	labels["headers"] = "Headers"
+	labels["body"] = "Body"
+	labels["response"] = "Response"
+	labels["truncated"] = "(truncated)"
+
+	return &HistoryView{
+		App:    app,
+		AppCtx: ctx,
+		Labels: labels,
+		Reopen: reopen,
+		Replay: replay,
+		Clear:  clear,
+	}
+}
+
+// InitView builds all components to display correctly the view
+func (view *HistoryView) InitView() {
+	view.DetailPrmt = tview.NewTextView()
+	view.DetailPrmt.SetBackgroundColor(utils.BackGrayColor)
+	view.DetailPrmt.SetDynamicColors(true).SetScrollable(true).SetWrap(true)
+	view.DetailPrmt.SetBorderPadding(1, 1, 1, 1)
+
+	view.TablePrmt = tview.NewTable().SetBorders(false).SetSelectable(true, false)
+	view.TablePrmt.SetBackgroundColor(utils.BackGrayColor)
+	view.TablePrmt.SetSelectionChangedFunc(func(row int, column int) {
+		if entry, ok := view.getEntry(row); ok {
+			view.displayDetail(entry)
+		}
+	})
+	view.TablePrmt.SetSelectedFunc(func(row int, column int) {
+		if entry, ok := view.getEntry(row); ok {
+			view.Reopen(entry)
+		}
+	})
+	view.TablePrmt.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		row, _ := view.TablePrmt.GetSelection()
+		switch event.Rune() {
+		case 'r':
+			if entry, ok := view.getEntry(row); ok {
+				view.Replay(entry)
+			}
+			return nil
+		case 'o':
+			if entry, ok := view.getEntry(row); ok {
+				view.Reopen(entry)
+			}
+			return nil
+		case 'x':
+			view.Clear()
+			return nil
+		}
+		return event
+	})
+
+	titlePrmt := utils.MakeTitlePrmt(view.Labels["title"])
+	titlePrmt.AddItem(view.TablePrmt, 0, 1, false)
+
+	flex := tview.NewFlex()
+	flex.AddItem(titlePrmt, 0, 1, false)
+	flex.AddItem(tview.NewBox().SetBorder(false), 2, 0, false)
+	flex.AddItem(view.DetailPrmt, 0, 1, false)
+
+	view.ParentPrmt = tview.NewFrame(flex).SetBorders(0, 0, 0, 0, 0, 0)
+
+	view.AppCtx.AddListenerHistory["historyView"] = func(history models.History) {
+		view.AppCtx.PrintTrace("HistoryView.InitView{...}.AddListenerHistory")
+
+		view.refresh(history)
+	}
+}
+
+func (view *HistoryView) refresh(history models.History) {
+	view.entries = history.Entries
+	view.TablePrmt.Clear()
+	view.DetailPrmt.SetText("")
+
+	if len(view.entries) == 0 {
+		view.TablePrmt.SetCell(0, 0, tview.NewTableCell(view.Labels["empty"]).SetSelectable(false))
+		return
+	}
+
+	for row, entry := range view.entries {
+		label := entry.Request.Alias
+		if label == "" {
+			label = entry.URL
+		}
+
+		view.TablePrmt.SetCell(row, 0, tview.NewTableCell(entry.Date.Format("01/02 15:04:05")).SetTextColor(tcell.ColorYellow))
+		view.TablePrmt.SetCell(row, 1, tview.NewTableCell(entry.Request.Method.Label()).SetTextColor(tcell.GetColor(utils.BlueColorName)))
+		view.TablePrmt.SetCell(row, 2, tview.NewTableCell(view.statusCode(entry)).SetTextColor(view.statusColor(entry)))
+		view.TablePrmt.SetCell(row, 3, tview.NewTableCell(entry.Duration.Round(time.Millisecond).String()).SetAlign(tview.AlignRight))
+		view.TablePrmt.SetCell(row, 4, tview.NewTableCell(label).SetExpansion(1))
+	}
+	view.TablePrmt.Select(0, 0).ScrollToBeginning()
+	view.displayDetail(view.entries[0])
+}
+
+func (view *HistoryView) getEntry(row int) (models.HistoryEntry, bool) {
+	if row < 0 || row >= len(view.entries) {
+		return models.HistoryEntry{}, false
+	}
+	return view.entries[row], true
+}
+
+func (view *HistoryView) statusCode(entry models.HistoryEntry) string {
+	if entry.StatusCode == 0 {
+		return "---"
+	}
+	return strconv.Itoa(entry.StatusCode)
+}
+
+func (view *HistoryView) statusColor(entry models.HistoryEntry) tcell.Color {
+	if entry.StatusCode >= 200 && entry.StatusCode < 400 {
+		return tcell.GetColor(utils.GreenColorName)
+	}
+	return tcell.ColorRed
+}
+
+func (view *HistoryView) displayDetail(entry models.HistoryEntry) {
+	format := func(key string, value string) string {
+		return "[yellow]" + key + "[white]: " + tview.Escape(value) + "\r\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(format(view.Labels["date"], entry.Date.Format("2006-01-02 15:04:05")))
+	sb.WriteString(format(view.Labels["context"], entry.Context))
+	sb.WriteString(format(view.Labels["status"], entry.StatusLabel()))
+	sb.WriteString(format(view.Labels["duration"], entry.Duration.String()))
+	sb.WriteString("\r\n")
+
+	sb.WriteString("[" + utils.BlueColorName + "]" + entry.Method + "[white] " + tview.Escape(entry.URL) + "\r\n\r\n")
+
+	sb.WriteString("[yellow]" + view.Labels["headers"] + ":\r\n")
+	for _, key := range core.StringMap(entry.Headers).ToSortedKeys() {
+		sb.WriteString("[" + utils.BlueColorName + "]" + key + "[white] " + tview.Escape(entry.Headers[key]) + "\r\n")
+	}
+	sb.WriteString("\r\n")
+
+	sb.WriteString("[yellow]" + view.Labels["body"] + ":[white]\r\n")
+	sb.WriteString(tview.Escape(entry.Body) + "\r\n\r\n")
+
+	sb.WriteString("[yellow]" + view.Labels["response"] + ":[white]")
+	if entry.Truncated {
+		sb.WriteString(" " + view.Labels["truncated"])
+	}
+	sb.WriteString("\r\n")
+	sb.WriteString(tview.Escape(entry.Response))
+
+	view.DetailPrmt.SetText(sb.String()).ScrollToBeginning()
+}
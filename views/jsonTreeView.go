@@ -0,0 +1,283 @@
+package views
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/joakim-ribier/gttp/core"
+	"github.com/joakim-ribier/gttp/models"
+	"github.com/joakim-ribier/gttp/utils"
+	"github.com/rivo/tview"
+)
+
+// JSONTreeView represents the collapsible tree of the json response body
+type JSONTreeView struct {
+	App    *tview.Application
+	AppCtx *models.AppCtx
+
+	Labels map[string]string
+
+	TreePrmt       *tview.TreeView
+	BreadcrumbPrmt *tview.TextView
+	DetailPrmt     *tview.TextView
+	VariablePrmt   *tview.InputField
+	MessagePrmt    *tview.TextView
+	ParentPrmt     tview.Primitive
+
+	// Actions
+	Copy        func(value string)
+	AddVariable func(variable string, value string) error
+}
+
+// jsonTreeNode represents the json value of a node of the tree
+type jsonTreeNode struct {
+	path   string
+	value  interface{}
+	parent *tview.TreeNode
+}
+
+// NewJSONTreeView returns the view for the json tree explorer
+func NewJSONTreeView(
+	app *tview.Application,
+	ctx *models.AppCtx,
+	copy func(value string),
+	addVariable func(variable string, value string) error) *JSONTreeView {
+
+	labels := make(map[string]string)
+	labels["title"] = "JSON Explorer"
+	labels["empty"] = "No json response to explore, execute a request first..."
+	labels["path"] = "Path"
+	labels["value"] = "Value"
+	labels["variable"] = "Context variable"
+	labels["copied"] = "copied to the clipboard"
+	labels["added"] = "added to the execution context"
+	labels["help"] = "[" + utils.BlueColorName + "]Enter[white] expand/collapse, " +
+		"[" + utils.BlueColorName + "]c[white] copy value, " +
+		"[" + utils.BlueColorName + "]p[white] copy JSONPath, " +
+		"[" + utils.BlueColorName + "]v[white] save value as context variable"
+
+	return &JSONTreeView{
+		App:         app,
+		AppCtx:      ctx,
+		Labels:      labels,
+		Copy:        copy,
+		AddVariable: addVariable,
+	}
+}
+
+// InitView builds all components to display correctly the view
+func (view *JSONTreeView) InitView() {
+	view.TreePrmt = tview.NewTreeView()
+	view.TreePrmt.SetBackgroundColor(utils.BackGrayColor)
+	view.TreePrmt.SetGraphicsColor(tcell.ColorGray)
+	view.TreePrmt.SetSelectedFunc(func(node *tview.TreeNode) {
+		view.toggle(node)
+	})
+	view.TreePrmt.SetChangedFunc(func(node *tview.TreeNode) {
+		view.displayDetail(node)
+	})
+	view.TreePrmt.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		node := view.TreePrmt.GetCurrentNode()
+		if node == nil {
+			return event
+		}
+		switch event.Key() {
+		case tcell.KeyRight:
+			view.expand(node)
+			return nil
+		case tcell.KeyLeft:
+			if node.IsExpanded() && len(node.GetChildren()) > 0 {
+				node.Collapse()
+			} else if parent := jsonTreeNodeOf(node).parent; parent != nil {
+				view.TreePrmt.SetCurrentNode(parent)
+				view.displayDetail(parent)
+			}
+			return nil
+		}
+		switch event.Rune() {
+		case 'c':
+			view.Copy(core.JSONValueToString(jsonTreeNodeOf(node).value))
+			view.message("[" + utils.GreenColorName + "]" + view.Labels["value"] + " " + view.Labels["copied"])
+			return nil
+		case 'p':
+			view.Copy(jsonTreeNodeOf(node).path)
+			view.message("[" + utils.GreenColorName + "]" + tview.Escape(jsonTreeNodeOf(node).path) + " " + view.Labels["copied"])
+			return nil
+		case 'v':
+			view.App.SetFocus(view.VariablePrmt)
+			return nil
+		}
+		return event
+	})
+
+	view.BreadcrumbPrmt = tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+	view.BreadcrumbPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	view.DetailPrmt = tview.NewTextView().SetDynamicColors(true).SetScrollable(true).SetWrap(true)
+	view.DetailPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	view.VariablePrmt = tview.NewInputField().SetLabel(view.Labels["variable"] + " ")
+	view.VariablePrmt.SetBackgroundColor(utils.BackGrayColor)
+	view.VariablePrmt.SetFieldBackgroundColor(utils.BackColor)
+	view.VariablePrmt.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			view.addVariable()
+		}
+		view.App.SetFocus(view.TreePrmt)
+	})
+
+	view.MessagePrmt = tview.NewTextView().SetDynamicColors(true)
+	view.MessagePrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	titlePrmt := utils.MakeTitlePrmt(view.Labels["title"])
+	titlePrmt.AddItem(view.TreePrmt, 0, 1, false)
+
+	detailPrmt := tview.NewFlex().SetDirection(tview.FlexRow)
+	detailPrmt.SetBackgroundColor(utils.BackGrayColor)
+	detailPrmt.SetBorderPadding(1, 1, 1, 1)
+	detailPrmt.AddItem(view.BreadcrumbPrmt, 2, 0, false)
+	detailPrmt.AddItem(view.DetailPrmt, 0, 1, false)
+	detailPrmt.AddItem(view.VariablePrmt, 1, 0, false)
+	detailPrmt.AddItem(view.MessagePrmt, 2, 0, false)
+	detailPrmt.AddItem(tview.NewTextView().SetDynamicColors(true).SetText(view.Labels["help"]).SetBackgroundColor(utils.BackGrayColor), 2, 0, false)
+
+	flex := tview.NewFlex()
+	flex.AddItem(titlePrmt, 0, 1, false)
+	flex.AddItem(tview.NewBox().SetBorder(false), 2, 0, false)
+	flex.AddItem(detailPrmt, 0, 1, false)
+
+	view.ParentPrmt = tview.NewFrame(flex).SetBorders(0, 0, 0, 0, 0, 0)
+
+	view.Display("")
+}
+
+// Display parses the json @body and displays its tree (only the first level is expanded)
+func (view *JSONTreeView) Display(body string) error {
+	view.message("")
+	view.VariablePrmt.SetText("")
+
+	if body == "" {
+		view.TreePrmt.SetRoot(tview.NewTreeNode(view.Labels["empty"]).SetSelectable(false))
+		view.BreadcrumbPrmt.SetText("")
+		view.DetailPrmt.SetText("")
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewBufferString(body))
+	decoder.UseNumber()
+
+	var value interface{}
+	if error := decoder.Decode(&value); error != nil {
+		return error
+	}
+
+	root := view.makeNode("$", "$", value, nil)
+	view.expand(root)
+
+	view.TreePrmt.SetRoot(root).SetCurrentNode(root)
+	view.displayDetail(root)
+	return nil
+}
+
+func (view *JSONTreeView) makeNode(label string, path string, value interface{}, parent *tview.TreeNode) *tview.TreeNode {
+	text := "[" + utils.BlueColorName + "]" + tview.Escape(label) + "[white]"
+	switch v := value.(type) {
+	case map[string]interface{}:
+		text += " [gray]{" + strconv.Itoa(len(v)) + "}"
+	case []interface{}:
+		text += " [gray]" + tview.Escape("["+strconv.Itoa(len(v))+"]")
+	case string:
+		text += ": [" + utils.GreenColorName + "]" + tview.Escape(strconv.Quote(v))
+	default:
+		text += ": " + tview.Escape(core.JSONValueToString(v))
+	}
+
+	return tview.NewTreeNode(text).
+		SetReference(jsonTreeNode{path: path, value: value, parent: parent}).
+		SetSelectable(true)
+}
+
+// expand adds the children of the @node (built only when the node is expanded the first time)
+func (view *JSONTreeView) expand(node *tview.TreeNode) {
+	if len(node.GetChildren()) == 0 {
+		ref := jsonTreeNodeOf(node)
+		switch v := ref.value.(type) {
+		case map[string]interface{}:
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				node.AddChild(view.makeNode(key, core.JSONPathChild(ref.path, key), v[key], node))
+			}
+		case []interface{}:
+			for index, item := range v {
+				node.AddChild(view.makeNode(strconv.Itoa(index), core.JSONPathItem(ref.path, index), item, node))
+			}
+		}
+	}
+	node.Expand()
+}
+
+func (view *JSONTreeView) toggle(node *tview.TreeNode) {
+	if node.IsExpanded() && len(node.GetChildren()) > 0 {
+		node.Collapse()
+	} else {
+		view.expand(node)
+	}
+}
+
+// displayDetail displays the path breadcrumb & the value of the selected @node
+func (view *JSONTreeView) displayDetail(node *tview.TreeNode) {
+	if _, ok := node.GetReference().(jsonTreeNode); !ok {
+		return
+	}
+	ref := jsonTreeNodeOf(node)
+
+	view.BreadcrumbPrmt.SetText("[yellow]" + view.Labels["path"] + " [white]" + tview.Escape(ref.path))
+
+	value := core.JSONValueToString(ref.value)
+	switch ref.value.(type) {
+	case map[string]interface{}, []interface{}:
+		if pretty, error := utils.PrettyJSON(value); error == nil {
+			value = pretty
+		} else {
+			value = tview.Escape(value)
+		}
+	default:
+		value = tview.Escape(value)
+	}
+	view.DetailPrmt.SetText(value).ScrollToBeginning()
+}
+
+// addVariable saves the value of the selected node in the context variable
+func (view *JSONTreeView) addVariable() {
+	node := view.TreePrmt.GetCurrentNode()
+	if node == nil || view.VariablePrmt.GetText() == "" {
+		return
+	}
+	if _, ok := node.GetReference().(jsonTreeNode); !ok {
+		return
+	}
+
+	variable := view.VariablePrmt.GetText()
+	if error := view.AddVariable(variable, core.JSONValueToString(jsonTreeNodeOf(node).value)); error != nil {
+		view.message("[red]" + tview.Escape(error.Error()))
+		return
+	}
+	view.message("[" + utils.GreenColorName + "]" + tview.Escape(variable) + " " + view.Labels["added"])
+	view.VariablePrmt.SetText("")
+}
+
+func (view *JSONTreeView) message(text string) {
+	view.MessagePrmt.SetText(text)
+}
+
+func jsonTreeNodeOf(node *tview.TreeNode) jsonTreeNode {
+	ref, _ := node.GetReference().(jsonTreeNode)
+	return ref
+}
@@ -3,6 +3,7 @@ package views
 import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/joakim-ribier/gttp/components"
+	"github.com/joakim-ribier/gttp/core"
 	"github.com/joakim-ribier/gttp/models"
 	"github.com/joakim-ribier/gttp/models/types"
 	"github.com/joakim-ribier/gttp/utils"
@@ -20,7 +21,7 @@ type MakeRequestView struct {
 	FormPrmt *tview.Form
 
 	// Actions
-	Save   func(callback func())
+	Save   func(previous models.MakeRequestData, callback func())
 	Remove func(callback func())
 }
 
@@ -28,7 +29,7 @@ type MakeRequestView struct {
 func NewMakeRequestView(
 	app *tview.Application,
 	ctx *models.AppCtx,
-	save func(callback func()),
+	save func(previous models.MakeRequestData, callback func()),
 	remove func(callback func())) *MakeRequestView {
 
 	labels := make(map[string]string)
@@ -40,10 +41,12 @@ func NewMakeRequestView(
 	labels["expert_mode"] = "([::ub]H[-:-:-])Expert mode"
 	labels["delete_request"] = "[::ub]D[-:-:-]elete"
 	labels["new_request"] = "[::ub]N[-:-:-]ew"
+	labels["import_curl"] = "c[::ub]U[-:-:-]rl"
 	labels["project"] = "Project"
 	labels["alias"] = "Alias"
 	labels["cancel"] = "Cancel"
 	labels["save"] = "Save"
+	labels["custom_method"] = "Method"
 
 	return &MakeRequestView{
 		App:    app,
@@ -58,9 +61,8 @@ func NewMakeRequestView(
 func (view *MakeRequestView) InitView(
 	executeRequest func(),
 	displayExpertMode func(),
-	newRequest func()) {
-
-	methodValues := utils.MethodValues
+	newRequest func(),
+	importCurl func()) {
 
 	flex := tview.NewFlex()
 	flex.SetBorder(false)
@@ -76,31 +78,55 @@ func (view *MakeRequestView) InitView(
 		envs := view.AppCtx.GetOutput().Context.GetEnvsName()
 
 		prmt := utils.GetDropDownFieldForm(formPrmt, view.Labels["execution_context"])
+		_, current := prmt.GetCurrentOption()
 		prmt.SetOptions(envs, nil)
 
-		index := envs.GetIndex("default")
+		// keep the selected context (ex. variables updated by an extraction rule)
+		index := envs.GetIndex(current)
+		if index == -1 {
+			index = envs.GetIndex("default")
+		}
 		prmt.SetCurrentOption(index)
 	}
 
 	// New Field - "Ex. Context"
 	formPrmt.AddDropDown(view.Labels["execution_context"], nil, 0, nil)
 
-	// New Field - "Request Method"
-	formPrmt.AddDropDown(view.Labels["request_method"], methodValues, 0, func(option string, index int) {
+	var refreshDropDownMethod func(method types.Method)
+
+	selectDropDownMethodOption := func(option string, index int) {
 		view.AppCtx.PrintTrace("MakeRequestView.InitView{...}.AddDropDown@" + view.Labels["request_method"])
 
+		if index == -1 {
+			return
+		}
+		if option == utils.CustomMethodValue {
+			view.DisplayCustomMethodView(refreshDropDownMethod)
+			return
+		}
+
 		makeRequestData := view.AppCtx.GetMDR()
 		makeRequestData.Method = types.Method(option)
 
 		view.AppCtx.UpdateMDR(makeRequestData)
-	})
+	}
+
+	refreshDropDownMethod = func(method types.Method) {
+		options := methodOptions(method)
+
+		prmt := utils.GetDropDownFieldForm(formPrmt, view.Labels["request_method"])
+		prmt.SetOptions(options, selectDropDownMethodOption)
+		prmt.SetCurrentOption(options.GetIndex(method.String()))
+	}
+
+	// New Field - "Request Method"
+	formPrmt.AddDropDown(view.Labels["request_method"], methodOptions(""), 0, selectDropDownMethodOption)
 
 	// New Field - "Request URL"
 	formPrmt.AddInputField(view.Labels["request_url"], view.AppCtx.GetMDR().URL.String(), 0, nil, func(text string) {
 		view.AppCtx.PrintTrace("MakeRequestView.InitView{...}.AddInputField@" + view.Labels["request_url"])
 
-		makeRequestData := view.AppCtx.GetMDR()
-		makeRequestData.URL = types.URL(text)
+		makeRequestData := view.AppCtx.GetMDR().SetURL(types.URL(text))
 
 		view.AppCtx.UpdateMDR(makeRequestData)
 	})
@@ -141,6 +167,13 @@ func (view *MakeRequestView) InitView(
 		newRequest()
 	})
 
+	// New Field - "Import curl"
+	formPrmt.AddButton(view.Labels["import_curl"], func() {
+		view.AppCtx.PrintTrace("MakeRequestView.InitView{...}.AddButton@" + view.Labels["import_curl"])
+
+		importCurl()
+	})
+
 	// New Field - "Delete Request"
 	formPrmt.AddButton(view.Labels["delete_request"], func() {
 		view.AppCtx.PrintTrace("MakeRequestView.InitView{...}.AddButton@" + view.Labels["delete_request"])
@@ -154,8 +187,7 @@ func (view *MakeRequestView) InitView(
 
 		utils.GetInputFieldForm(formPrmt, view.Labels["request_url"]).SetText(makeRequestData.URL.String())
 
-		methodSelectedIndex := methodValues.GetIndex(makeRequestData.Method.String())
-		utils.GetDropDownFieldForm(formPrmt, view.Labels["request_method"]).SetCurrentOption(methodSelectedIndex)
+		refreshDropDownMethod(makeRequestData.Method)
 	}
 
 	view.AppCtx.AddContextListener["refreshRequestPanelView"] = func(context models.Context) {
@@ -180,6 +212,14 @@ func (view *MakeRequestView) GetContext() (int, string) {
 	return utils.GetDropDownFieldForm(view.FormPrmt, view.Labels["execution_context"]).GetCurrentOption()
 }
 
+// SetContext selects the @env value in the context dropdown prmt (if exists)
+func (view *MakeRequestView) SetContext(env string) {
+	prmt := utils.GetDropDownFieldForm(view.FormPrmt, view.Labels["execution_context"])
+	if index := view.AppCtx.GetOutput().Context.GetEnvsName().GetIndex(env); index != -1 {
+		prmt.SetCurrentOption(index)
+	}
+}
+
 // DisplaySaveView displays request saving/updating view
 func (view *MakeRequestView) DisplaySaveView() {
 	textViewError := tview.NewTextView()
@@ -204,12 +244,13 @@ func (view *MakeRequestView) DisplaySaveView() {
 	// New Field - "Save"
 	form.AddButton(view.Labels["save"], func() {
 		if mrd := view.AppCtx.GetMDR(); mrd.URL != "" {
+			previous := mrd
 			mrd.ProjectName = utils.GetInputFieldForm(form, view.Labels["project"]).GetText()
 			mrd.Alias = utils.GetInputFieldForm(form, view.Labels["alias"]).GetText()
 
 			view.AppCtx.UpdateMDR(mrd)
 
-			view.Save(func() {
+			view.Save(previous, func() {
 				view.AppCtx.CloseModal()
 			})
 		} else {
@@ -227,7 +268,55 @@ func (view *MakeRequestView) DisplaySaveView() {
 	view.App.SetFocus(form)
 }
 
-// DisplaySaveView displays request saving/updating view
+// DisplayCustomMethodView displays the view to define a custom (non standard) request method
+func (view *MakeRequestView) DisplayCustomMethodView(done func(method types.Method)) {
+	textViewError := tview.NewTextView()
+	textViewError.SetTextColor(tcell.ColorRed)
+	textViewError.SetText("")
+
+	form := tview.NewForm()
+
+	// New field - "Method"
+	value := view.AppCtx.GetMDR().Method
+	if value.IsStandard() {
+		value = ""
+	}
+	form.AddInputField(view.Labels["custom_method"], value.String(), 0, nil, nil)
+	utils.AddInputFieldEventForm(form, view.Labels["custom_method"])
+
+	// New Field - "Cancel"
+	form.AddButton(view.Labels["cancel"], func() {
+		view.AppCtx.CloseModal()
+		done(view.AppCtx.GetMDR().Method)
+	})
+
+	// New Field - "Save"
+	form.AddButton(view.Labels["save"], func() {
+		method := types.Method(utils.GetInputFieldForm(form, view.Labels["custom_method"]).GetText())
+		if error := method.Validate(); error != nil {
+			textViewError.SetText(" " + error.Error())
+			return
+		}
+
+		mrd := view.AppCtx.GetMDR()
+		mrd.Method = method
+		view.AppCtx.UpdateMDR(mrd)
+
+		view.AppCtx.CloseModal()
+		done(method)
+	})
+
+	flexPrmt := tview.NewFlex().SetDirection(tview.FlexRow)
+	flexPrmt.SetBorder(true).SetTitle(" Custom Method ")
+	flexPrmt.AddItem(form, 0, 1, true)
+	flexPrmt.AddItem(textViewError, 1, 0, false)
+
+	view.AppCtx.DisplayModal(components.BuildModal(flexPrmt, 45, 8))
+
+	view.App.SetFocus(form)
+}
+
+// DisplayRemoveView displays request removing view
 func (view *MakeRequestView) DisplayRemoveView() {
 	modal := components.BuildYesNoModal(
 		"Do you confirm the deletion?",
@@ -242,3 +331,27 @@ func (view *MakeRequestView) DisplayRemoveView() {
 		})
 	view.AppCtx.DisplayModal(modal)
 }
+
+// DisplayConfirmView displays a confirmation view, the @confirm action is executed on "yes"
+func (view *MakeRequestView) DisplayConfirmView(message string, title string, confirm func()) {
+	modal := components.BuildYesNoModal(
+		message,
+		title, func() {
+			view.AppCtx.CloseModal()
+		}, func() {
+			view.AppCtx.CloseModal()
+			confirm()
+		}, func(form tview.Primitive) {
+			view.App.SetFocus(form)
+		})
+	view.AppCtx.DisplayModal(modal)
+}
+
+// methodOptions returns the standard methods, the @method if it's a custom one and the "custom" option
+func methodOptions(method types.Method) core.StringSlice {
+	options := append(core.StringSlice{}, utils.MethodValues...)
+	if method != "" && !method.IsStandard() {
+		options = append(options, method.String())
+	}
+	return append(options, utils.CustomMethodValue)
+}
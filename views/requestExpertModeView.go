@@ -4,6 +4,8 @@ import (
 	"strings"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/joakim-ribier/gttp/converters"
+	"github.com/joakim-ribier/gttp/core"
 	"github.com/joakim-ribier/gttp/models"
 	"github.com/joakim-ribier/gttp/utils"
 	"github.com/rivo/tview"
@@ -18,24 +20,56 @@ type RequestExpertModeView struct {
 
 	TitlePrmt  tview.Primitive
 	ParentPrmt tview.Primitive
+
+	// Actions
+	MaskSecrets    func(value string) string
+	ResolveSnippet func(makeRequestData models.MakeRequestData, env string) models.ResolvedRequest
 }
 
 // NewRequestExpertModeView returns the view for the request expert mode
-func NewRequestExpertModeView(app *tview.Application, ev *models.AppCtx) *RequestExpertModeView {
+func NewRequestExpertModeView(
+	app *tview.Application,
+	ev *models.AppCtx,
+	maskSecrets func(value string) string,
+	resolveSnippet func(makeRequestData models.MakeRequestData, env string) models.ResolvedRequest) *RequestExpertModeView {
+
 	labels := make(map[string]string)
 	labels["menu_content_type_title"] = "Define specific \"Content-Type\""
 	labels["menu_content_type_desc"] = "application/json,text/plain,multipart/f..."
 	labels["menu_header_title"] = "Add request Header"
-	labels["menu_header_desc"] = "& {param} url or {value} ex. context"
+	labels["menu_header_desc"] = "ex. Accept or {value} ex. context"
+	labels["menu_query_title"] = "Add URL Query params"
+	labels["menu_query_desc"] = "enable/disable ?key=value of the URL"
+	labels["menu_path_title"] = "Define URL path Variables"
+	labels["menu_path_desc"] = "ex. {id} of http://host/users/{id}"
 	labels["menu_body_title"] = "Add request Body"
 	labels["menu_body_desc"] = ""
 	labels["menu_preview_title"] = "Display request"
 	labels["menu_preview_desc"] = ""
+	labels["menu_export_title"] = "Export as..."
+	labels["menu_export_desc"] = "curl, HTTPie, wget or Go snippet"
+	labels["menu_timeout_title"] = "Define request Timeouts"
+	labels["menu_timeout_desc"] = "override the default settings timeouts"
+	labels["menu_assertion_title"] = "Add response Assertions"
+	labels["menu_assertion_desc"] = "status, header, body, JSONPath, duration"
+	labels["menu_auth_title"] = "Define request Authentication"
+	labels["menu_auth_desc"] = "Basic, Bearer token, API key or Digest"
+	labels["menu_extraction_title"] = "Extract response values"
+	labels["menu_extraction_desc"] = "into context variables (ex. {token})"
 
 	labels["title"] = "Request Expert Mode"
 	labels["requestPreview"] = "Request Preview"
-	labels["headers"] = "Headers/Params"
-	labels["headersPreview"] = "Headers/Params Preview"
+	labels["headers"] = "Headers"
+	labels["headersPreview"] = "Headers Preview"
+	labels["headerParamError"] = "Define the {param} of the URL in the path variables page"
+	labels["queryParams"] = "Query params"
+	labels["queryParamsPreview"] = "Query params Preview"
+	labels["enabled"] = "Enabled"
+	labels["pathParams"] = "Path variables"
+	labels["pathParamsPreview"] = "Path variables Preview"
+	labels["pathParamsHelp"] = "Replaced in the URL before the execution context, ex.:\r\n\r\n" +
+		"* http://{host}/users/{id} => Key: id, Value: 42\r\n\r\n" +
+		"The value can contain a {variable} of the execution context."
 	labels["key"] = "Key"
 	labels["value"] = "Value"
 	labels["body"] = "Body"
@@ -48,11 +82,69 @@ func NewRequestExpertModeView(app *tview.Application, ev *models.AppCtx) *Reques
 	labels["alias"] = "Alias"
 	labels["method"] = "Method"
 	labels["url"] = "URL"
+	labels["connectTimeout"] = "Connect timeout"
+	labels["totalTimeout"] = "Total timeout"
+	labels["timeoutPreview"] = "Timeouts Preview"
+	labels["timeoutDefault"] = "(default)"
+	labels["save"] = "Save"
+	labels["context"] = "Execution Context"
+	labels["format"] = "Format"
+	labels["copy"] = "Copy"
+	labels["exportPreview"] = "Snippet Preview"
+	labels["copied"] = "Copied to the clipboard!"
+	labels["assertions"] = "Assertions"
+	labels["assertionsPreview"] = "Assertions Preview"
+	labels["assertionType"] = "Type"
+	labels["assertionTarget"] = "Header / JSONPath"
+	labels["assertionValue"] = "Expected"
+	labels["auth"] = "Authentication"
+	labels["authPreview"] = "Authentication Preview"
+	labels["authType"] = "Type"
+	labels[models.AuthNone] = "None"
+	labels[models.AuthBasic] = "Basic"
+	labels[models.AuthBearer] = "Bearer token"
+	labels[models.AuthAPIKeyHeader] = "API key (header)"
+	labels[models.AuthAPIKeyQuery] = "API key (query)"
+	labels[models.AuthDigest] = "Digest"
+	labels["authUsername"] = "Username"
+	labels["authPassword"] = "Password"
+	labels["authToken"] = "Token / Value"
+	labels["authKey"] = "Key name"
+	labels["authHelp"] = "Applied at each execution, ex.:\r\n\r\n" +
+		"* Basic            => Username: bob, Password: {password}\r\n" +
+		"* Bearer token     => Token: {token}\r\n" +
+		"* API key (header) => Key name: X-API-Key, Token / Value: {api_key}\r\n" +
+		"* API key (query)  => Key name: api_key, Token / Value: {api_key}\r\n" +
+		"* Digest           => Username: bob, Password: {password}\r\n\r\n" +
+		"The {variable} of the execution context are replaced, " +
+		"keep the secrets in the context instead of the request."
+	labels["extractions"] = "Extractions"
+	labels["extractionsPreview"] = "Extractions Preview"
+	labels["extractionSource"] = "Source"
+	labels["extractionExpression"] = "Expression"
+	labels["extractionVariable"] = "Variable"
+	labels["extractionHelp"] = "Applied after each execution, the extracted value is saved " +
+		"in the variable of the selected execution context, ex.:\r\n\r\n" +
+		"* JSONPath => Expression: $.access_token, Variable: token\r\n" +
+		"* Header   => Expression: Location, Variable: location\r\n" +
+		"* Regex    => Expression: id=(\\d+), Variable: id (first group)\r\n\r\n" +
+		"Use the {token} variable in the next requests."
+	labels["assertionHelp"] = "Evaluated after each execution, ex.:\r\n\r\n" +
+		"* Status equals            => 200\r\n" +
+		"* Status in range          => 200-299 or 2xx\r\n" +
+		"* Header exists            => Header: X-Request-Id\r\n" +
+		"* Header matches (regex)   => Header: Content-Type, Expected: ^application/json\r\n" +
+		"* Body contains            => \"status\":\"ok\"\r\n" +
+		"* JSONPath equals          => JSONPath: $.user.id, Expected: 42\r\n" +
+		"* Duration below           => 500ms\r\n\r\n" +
+		"The {variable} of the execution context are replaced."
 
 	return &RequestExpertModeView{
-		App:    app,
-		AppCtx: ev,
-		Labels: labels,
+		App:            app,
+		AppCtx:         ev,
+		Labels:         labels,
+		MaskSecrets:    maskSecrets,
+		ResolveSnippet: resolveSnippet,
 	}
 }
 
@@ -63,9 +155,16 @@ func (view *RequestExpertModeView) InitView() {
 	// Make pages for each menu content
 	pages := tview.NewPages()
 	pages.SetBackgroundColor(utils.BackGrayColor)
+	pages.AddPage("AddAssertionPage", view.makeAddAssertionPage(mapMenuToFocusPrmt), true, false)
 	pages.AddPage("AddContentTypePage", view.makeAddContentTypePage(mapMenuToFocusPrmt), true, false)
 	pages.AddPage("AddHeaderPage", view.makeAddHeaderPage(mapMenuToFocusPrmt), true, false)
+	pages.AddPage("QueryParamsPage", view.makeQueryParamsPage(mapMenuToFocusPrmt), true, false)
+	pages.AddPage("PathParamsPage", view.makePathParamsPage(mapMenuToFocusPrmt), true, false)
 	pages.AddPage("AddBodyPage", view.makeAddBodyPage(mapMenuToFocusPrmt), true, false)
+	pages.AddPage("ExportPage", view.makeExportPage(mapMenuToFocusPrmt), true, false)
+	pages.AddPage("AddExtractionPage", view.makeAddExtractionPage(mapMenuToFocusPrmt), true, false)
+	pages.AddPage("AuthPage", view.makeAuthPage(mapMenuToFocusPrmt), true, false)
+	pages.AddPage("TimeoutPage", view.makeTimeoutPage(mapMenuToFocusPrmt), true, false)
 	pages.AddPage("PreviewPage", view.makePreviewPage(), true, false)
 
 	// Make menu
@@ -103,6 +202,10 @@ func (view *RequestExpertModeView) InitView() {
 
 func (view *RequestExpertModeView) makeMenu(pages *tview.Pages, mapMenuToFocusPrmt map[string]tview.Primitive) *tview.List {
 	menu := tview.NewList().
+		AddItem(view.Labels["menu_assertion_title"], view.Labels["menu_assertion_desc"], 'a', func() {
+			pages.SwitchToPage("AddAssertionPage")
+			view.App.SetFocus(mapMenuToFocusPrmt["menu_assertion"])
+		}).
 		AddItem(view.Labels["menu_body_title"], view.Labels["menu_body_desc"], 'b', func() {
 			pages.SwitchToPage("AddBodyPage")
 			view.App.SetFocus(mapMenuToFocusPrmt["menu_body"])
@@ -111,10 +214,34 @@ func (view *RequestExpertModeView) makeMenu(pages *tview.Pages, mapMenuToFocusPr
 			pages.SwitchToPage("AddContentTypePage")
 			view.App.SetFocus(mapMenuToFocusPrmt["menu_content_type"])
 		}).
+		AddItem(view.Labels["menu_export_title"], view.Labels["menu_export_desc"], 'e', func() {
+			pages.SwitchToPage("ExportPage")
+			view.App.SetFocus(mapMenuToFocusPrmt["menu_export"])
+		}).
 		AddItem(view.Labels["menu_header_title"], view.Labels["menu_header_desc"], 'h', func() {
 			pages.SwitchToPage("AddHeaderPage")
 			view.App.SetFocus(mapMenuToFocusPrmt["menu_header"])
 		}).
+		AddItem(view.Labels["menu_query_title"], view.Labels["menu_query_desc"], 'q', func() {
+			pages.SwitchToPage("QueryParamsPage")
+			view.App.SetFocus(mapMenuToFocusPrmt["menu_query"])
+		}).
+		AddItem(view.Labels["menu_timeout_title"], view.Labels["menu_timeout_desc"], 't', func() {
+			pages.SwitchToPage("TimeoutPage")
+			view.App.SetFocus(mapMenuToFocusPrmt["menu_timeout"])
+		}).
+		AddItem(view.Labels["menu_auth_title"], view.Labels["menu_auth_desc"], 'u', func() {
+			pages.SwitchToPage("AuthPage")
+			view.App.SetFocus(mapMenuToFocusPrmt["menu_auth"])
+		}).
+		AddItem(view.Labels["menu_path_title"], view.Labels["menu_path_desc"], 'v', func() {
+			pages.SwitchToPage("PathParamsPage")
+			view.App.SetFocus(mapMenuToFocusPrmt["menu_path"])
+		}).
+		AddItem(view.Labels["menu_extraction_title"], view.Labels["menu_extraction_desc"], 'x', func() {
+			pages.SwitchToPage("AddExtractionPage")
+			view.App.SetFocus(mapMenuToFocusPrmt["menu_extraction"])
+		}).
 		AddItem(view.Labels["menu_preview_title"], view.Labels["menu_preview_desc"], 'p', func() {
 			pages.SwitchToPage("PreviewPage")
 			view.App.SetFocus(mapMenuToFocusPrmt["menu_preview"])
@@ -136,6 +263,10 @@ func (view *RequestExpertModeView) makeAddContentTypePage(mapMenuToFocusPrmt map
 
 	// Add "Content-Type" field
 	formPrmt.AddDropDown(view.Labels["contentType"], contentTypeValues, 1, func(option string, index int) {
+		// Custom content type (ex. imported request) not in the list
+		if index == -1 {
+			return
+		}
 		makeRequestData := view.AppCtx.GetMDR()
 		makeRequestData.ContentType = option
 
@@ -190,11 +321,13 @@ func (view *RequestExpertModeView) makeAddContentTypePage(mapMenuToFocusPrmt map
 func (view *RequestExpertModeView) makeAddHeaderPage(mapMenuToFocusPrmt map[string]tview.Primitive) *tview.Flex {
 	// Display headers preview
 	displayPreview := func(textView *tview.TextView) {
-		sortedHeaderKeys := view.AppCtx.GetMDR().MapRequestHeaderKeyValue.ToSortedKeys()
-		header := view.AppCtx.GetMDR().MapRequestHeaderKeyValue
+		makeRequestData := view.AppCtx.GetMDR()
+		header := makeRequestData.GetHeaders()
+		sortedHeaderKeys := header.ToSortedKeys()
 
 		var sb strings.Builder
 		for _, key := range sortedHeaderKeys {
+			sb.WriteString(tview.Escape(checkedText(makeRequestData.IsHeaderEnabled(key))) + " ")
 			sb.WriteString("[" + utils.BlueColorName + "]" + key + "[white] " + header[key])
 			sb.WriteString("\r\n\r\n")
 		}
@@ -219,14 +352,24 @@ func (view *RequestExpertModeView) makeAddHeaderPage(mapMenuToFocusPrmt map[stri
 	previewFlexPrmt.AddItem(tview.NewBox().SetBackgroundColor(utils.BackGrayColor), 1, 0, false)
 	previewFlexPrmt.AddItem(previewPrmt, 0, 1, false)
 
+	textViewError := tview.NewTextView()
+	textViewError.SetTextColor(tcell.ColorRed)
+	textViewError.SetBackgroundColor(utils.BackGrayColor)
+
 	// Make header form
 	formPrmt := tview.NewForm()
 	formPrmt.SetBorder(false)
 	formPrmt.SetBackgroundColor(utils.BackGrayColor)
 
-	selectedEventDropDown := func(key string) {
+	selectedEventDropDown := func(index int) {
 		makeRequestData := view.AppCtx.GetMDR()
-		value := makeRequestData.MapRequestHeaderKeyValue[key]
+		headers := makeRequestData.GetHeaders()
+		headerKeys := headers.ToSortedKeys()
+		if index < 0 || index >= len(headerKeys) {
+			return
+		}
+		key := headerKeys[index]
+		value := headers[key]
 
 		if item := utils.GetInputFieldForm(formPrmt, view.Labels["key"]); item != nil {
 			item.SetText(key)
@@ -235,27 +378,32 @@ func (view *RequestExpertModeView) makeAddHeaderPage(mapMenuToFocusPrmt map[stri
 		if item := utils.GetInputFieldForm(formPrmt, view.Labels["value"]); item != nil {
 			item.SetText(value)
 		}
+
+		formPrmt.GetFormItemByLabel(view.Labels["enabled"]).(*tview.Checkbox).SetChecked(makeRequestData.IsHeaderEnabled(key))
 	}
 
-	saveAndRefreshView := func(makeRequestData models.MakeRequestData) {
+	saveAndRefreshView := func(makeRequestData models.MakeRequestData, selected int) {
 		// update object
 		view.updateMDR(makeRequestData)
 
-		headerKeys := makeRequestData.MapRequestHeaderKeyValue.ToSortedKeys()
+		var options []string
+		for _, key := range makeRequestData.GetHeaders().ToSortedKeys() {
+			options = append(options, tview.Escape(checkedText(makeRequestData.IsHeaderEnabled(key))+" "+key))
+		}
 
 		dropDrownPrmt := utils.GetDropDownFieldForm(formPrmt, view.Labels["headers"])
-		dropDrownPrmt.SetOptions(headerKeys, func(option string, index int) {
-			selectedEventDropDown(option)
+		dropDrownPrmt.SetOptions(options, func(option string, index int) {
+			selectedEventDropDown(index)
 		})
 		// Very important, fill the component with values before to SetCurrentOption
-		dropDrownPrmt.SetCurrentOption(0)
+		dropDrownPrmt.SetCurrentOption(selected)
 
 		displayPreview(previewPrmt)
 	}
 
 	// Add "Headers" field
 	formPrmt.AddDropDown(view.Labels["headers"], nil, 0, func(option string, index int) {
-		selectedEventDropDown(option)
+		selectedEventDropDown(index)
 	})
 
 	// Add "Key" field
@@ -266,6 +414,19 @@ func (view *RequestExpertModeView) makeAddHeaderPage(mapMenuToFocusPrmt map[stri
 	formPrmt.AddInputField(view.Labels["value"], "", 0, nil, nil)
 	utils.AddInputFieldEventForm(formPrmt, view.Labels["value"])
 
+	// Add "Enabled" field, it toggles the selected header without the "Add" button
+	formPrmt.AddCheckbox(view.Labels["enabled"], true, func(checked bool) {
+		index, _ := utils.GetDropDownFieldForm(formPrmt, view.Labels["headers"]).GetCurrentOption()
+
+		makeRequestData := view.AppCtx.GetMDR()
+		headerKeys := makeRequestData.GetHeaders().ToSortedKeys()
+		if index < 0 || index >= len(headerKeys) || headerKeys[index] != utils.GetInputFieldForm(formPrmt, view.Labels["key"]).GetText() {
+			return
+		}
+
+		saveAndRefreshView(makeRequestData.SetHeaderEnabled(headerKeys[index], checked), index)
+	})
+
 	// Add "Add" button
 	formPrmt.AddButton(view.Labels["add"], func() {
 		makeRequestData := view.AppCtx.GetMDR()
@@ -276,50 +437,82 @@ func (view *RequestExpertModeView) makeAddHeaderPage(mapMenuToFocusPrmt map[stri
 		key := keyFieldPrmt.GetText()
 		value := valueFieldPrmt.GetText()
 
+		// the {param} of the URL are not headers
+		if strings.HasPrefix(key, "{") && strings.HasSuffix(key, "}") {
+			textViewError.SetText(view.Labels["headerParamError"])
+			return
+		}
+		textViewError.SetText("")
+
 		// add new value
-		makeRequestData.MapRequestHeaderKeyValue[key] = value
+		makeRequestData = makeRequestData.SetHeader(key, value, formPrmt.GetFormItemByLabel(view.Labels["enabled"]).(*tview.Checkbox).IsChecked())
 
-		saveAndRefreshView(makeRequestData)
+		saveAndRefreshView(makeRequestData, core.StringSlice(makeRequestData.GetHeaders().ToSortedKeys()).GetIndex(key))
 	})
 
 	// Add "Remove" button
 	formPrmt.AddButton(view.Labels["remove"], func() {
 		utils.GetInputFieldForm(formPrmt, view.Labels["key"]).SetText("")
 		utils.GetInputFieldForm(formPrmt, view.Labels["value"]).SetText("")
+		textViewError.SetText("")
 
 		dropDrownPrmt := utils.GetDropDownFieldForm(formPrmt, view.Labels["headers"])
 
 		makeRequestData := view.AppCtx.GetMDR()
+		headerKeys := makeRequestData.GetHeaders().ToSortedKeys()
+		index, _ := dropDrownPrmt.GetCurrentOption()
+		if index < 0 || index >= len(headerKeys) {
+			return
+		}
 		// delete value
-		_, value := dropDrownPrmt.GetCurrentOption()
-		delete(makeRequestData.MapRequestHeaderKeyValue, value)
+		makeRequestData = makeRequestData.RemoveHeader(headerKeys[index])
 
-		saveAndRefreshView(makeRequestData)
+		saveAndRefreshView(makeRequestData, 0)
 	})
 
 	// Add listener to refresh primitive when the MakeRequestData is changing...
 	view.AppCtx.AddListenerMRD["requestExpertModeViewHeaderPage"] = func(makeRequestData models.MakeRequestData) {
 		utils.GetInputFieldForm(formPrmt, view.Labels["key"]).SetText("")
 		utils.GetInputFieldForm(formPrmt, view.Labels["value"]).SetText("")
+		formPrmt.GetFormItemByLabel(view.Labels["enabled"]).(*tview.Checkbox).SetChecked(true)
+		textViewError.SetText("")
 
-		saveAndRefreshView(view.AppCtx.GetMDR())
+		saveAndRefreshView(view.AppCtx.GetMDR(), 0)
 	}
 
 	// Map menu with form
 	mapMenuToFocusPrmt["menu_header"] = formPrmt
 
+	formFlexPrmt := tview.NewFlex().SetDirection(tview.FlexRow)
+	formFlexPrmt.AddItem(formPrmt, 0, 1, false)
+	formFlexPrmt.AddItem(textViewError, 1, 0, false)
+
 	flex := tview.NewFlex()
 	flex.SetBorderPadding(1, 1, 1, 1)
-	flex.AddItem(formPrmt, 0, 1, false)
+	flex.AddItem(formFlexPrmt, 0, 1, false)
 	flex.AddItem(previewFlexPrmt, 0, 2, false)
 
 	return flex
 }
 
-func (view *RequestExpertModeView) makeAddBodyPage(mapMenuToFocusPrmt map[string]tview.Primitive) *tview.Flex {
+func (view *RequestExpertModeView) makeQueryParamsPage(mapMenuToFocusPrmt map[string]tview.Primitive) *tview.Flex {
+	// Display query params preview
+	displayPreview := func(textView *tview.TextView) {
+		makeRequestData := view.AppCtx.GetMDR().Normalized()
+
+		var sb strings.Builder
+		for _, param := range makeRequestData.QueryParams {
+			sb.WriteString(tview.Escape(checkedText(param.Enabled)) + " ")
+			sb.WriteString("[" + utils.BlueColorName + "]" + tview.Escape(param.Key) + "[white] " + tview.Escape(param.Value))
+			sb.WriteString("\r\n\r\n")
+		}
+		sb.WriteString("[yellow]" + view.Labels["url"] + "[white]: " + tview.Escape(view.MaskSecrets(makeRequestData.URL.String())))
+		textView.SetText(sb.String())
+	}
+
 	// Make preview prmt
 	previewTitlePrmt := tview.NewTextView()
-	previewTitlePrmt.SetText(view.Labels["bodyPreview"])
+	previewTitlePrmt.SetText(view.Labels["queryParamsPreview"])
 	previewTitlePrmt.SetTextColor(tcell.ColorGreen)
 	previewTitlePrmt.
 		SetTextAlign(tview.AlignCenter).
@@ -335,110 +528,1050 @@ func (view *RequestExpertModeView) makeAddBodyPage(mapMenuToFocusPrmt map[string
 	previewFlexPrmt.AddItem(tview.NewBox().SetBackgroundColor(utils.BackGrayColor), 1, 0, false)
 	previewFlexPrmt.AddItem(previewPrmt, 0, 1, false)
 
+	// Make query params form
 	formPrmt := tview.NewForm()
 	formPrmt.SetBorder(false)
 	formPrmt.SetBackgroundColor(utils.BackGrayColor)
 
-	// Add "Body" field
-	formPrmt.AddInputField(view.Labels["body"], "", 0, nil, func(value string) {
-		previewPrmt.SetText(value)
+	selectedEventDropDown := func(index int) {
+		params := view.AppCtx.GetMDR().Normalized().QueryParams
+		if index < 0 || index >= len(params) {
+			return
+		}
+		param := params[index]
+
+		utils.GetInputFieldForm(formPrmt, view.Labels["key"]).SetText(param.Key)
+		utils.GetInputFieldForm(formPrmt, view.Labels["value"]).SetText(param.Value)
+		formPrmt.GetFormItemByLabel(view.Labels["enabled"]).(*tview.Checkbox).SetChecked(param.Enabled)
+	}
+
+	saveAndRefreshView := func(makeRequestData models.MakeRequestData, selected int) {
+		// update object
+		view.updateMDR(makeRequestData)
+
+		var options []string
+		for _, param := range makeRequestData.Normalized().QueryParams {
+			options = append(options, tview.Escape(checkedText(param.Enabled)+" "+param.String()))
+		}
+
+		dropDrownPrmt := utils.GetDropDownFieldForm(formPrmt, view.Labels["queryParams"])
+		dropDrownPrmt.SetOptions(options, func(option string, index int) {
+			selectedEventDropDown(index)
+		})
+		// Very important, fill the component with values before to SetCurrentOption
+		dropDrownPrmt.SetCurrentOption(selected)
+
+		displayPreview(previewPrmt)
+	}
+
+	// Add "Query params" field
+	formPrmt.AddDropDown(view.Labels["queryParams"], nil, 0, func(option string, index int) {
+		selectedEventDropDown(index)
 	})
 
-	// Add generic events to inputField
-	utils.AddInputFieldEventForm(formPrmt, view.Labels["body"])
+	// Add "Key" field
+	formPrmt.AddInputField(view.Labels["key"], "", 0, nil, nil)
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["key"])
+
+	// Add "Value" field
+	formPrmt.AddInputField(view.Labels["value"], "", 0, nil, nil)
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["value"])
+
+	// Add "Enabled" field, it toggles the selected param without the "Add" button
+	formPrmt.AddCheckbox(view.Labels["enabled"], true, func(checked bool) {
+		index, _ := utils.GetDropDownFieldForm(formPrmt, view.Labels["queryParams"]).GetCurrentOption()
+
+		makeRequestData := view.AppCtx.GetMDR().Normalized()
+		params := makeRequestData.QueryParams
+		if index < 0 || index >= len(params) || params[index].Key != utils.GetInputFieldForm(formPrmt, view.Labels["key"]).GetText() {
+			return
+		}
+		params[index].Enabled = checked
+
+		saveAndRefreshView(makeRequestData.SetQueryParams(params), index)
+	})
 
 	// Add "Add" button
 	formPrmt.AddButton(view.Labels["add"], func() {
-		makeRequestData := view.AppCtx.GetMDR()
-		makeRequestData.Body = utils.GetInputFieldForm(formPrmt, view.Labels["body"]).GetText()
+		param := models.QueryParam{
+			Key:     strings.TrimSpace(utils.GetInputFieldForm(formPrmt, view.Labels["key"]).GetText()),
+			Value:   utils.GetInputFieldForm(formPrmt, view.Labels["value"]).GetText(),
+			Enabled: formPrmt.GetFormItemByLabel(view.Labels["enabled"]).(*tview.Checkbox).IsChecked(),
+		}
+		if param.Key == "" {
+			return
+		}
 
-		// Update request
-		view.updateMDR(makeRequestData)
-		previewPrmt.SetText(makeRequestData.Body)
+		makeRequestData := view.AppCtx.GetMDR().Normalized()
+		params := makeRequestData.QueryParams
+
+		// add new value or replace the value of the same key
+		index := len(params)
+		for i, value := range params {
+			if value.Key == param.Key {
+				index = i
+				break
+			}
+		}
+		if index == len(params) {
+			params = append(params, param)
+		} else {
+			params[index] = param
+		}
+
+		saveAndRefreshView(makeRequestData.SetQueryParams(params), index)
+	})
+
+	// Add "Remove" button
+	formPrmt.AddButton(view.Labels["remove"], func() {
+		utils.GetInputFieldForm(formPrmt, view.Labels["key"]).SetText("")
+		utils.GetInputFieldForm(formPrmt, view.Labels["value"]).SetText("")
+
+		index, _ := utils.GetDropDownFieldForm(formPrmt, view.Labels["queryParams"]).GetCurrentOption()
+
+		makeRequestData := view.AppCtx.GetMDR().Normalized()
+		if index < 0 || index >= len(makeRequestData.QueryParams) {
+			return
+		}
+		// delete value
+		params := append([]models.QueryParam{}, makeRequestData.QueryParams[:index]...)
+		params = append(params, makeRequestData.QueryParams[index+1:]...)
+
+		saveAndRefreshView(makeRequestData.SetQueryParams(params), 0)
 	})
 
 	// Add listener to refresh primitive when the MakeRequestData is changing...
-	view.AppCtx.AddListenerMRD["requestExpertModeViewBodyPage"] = func(makeRequestData models.MakeRequestData) {
-		utils.GetInputFieldForm(formPrmt, view.Labels["body"]).SetText(makeRequestData.Body)
-		previewPrmt.SetText(makeRequestData.Body)
+	view.AppCtx.AddListenerMRD["requestExpertModeViewQueryParamsPage"] = func(makeRequestData models.MakeRequestData) {
+		utils.GetInputFieldForm(formPrmt, view.Labels["key"]).SetText("")
+		utils.GetInputFieldForm(formPrmt, view.Labels["value"]).SetText("")
+		formPrmt.GetFormItemByLabel(view.Labels["enabled"]).(*tview.Checkbox).SetChecked(true)
+
+		saveAndRefreshView(view.AppCtx.GetMDR(), 0)
 	}
 
+	// Map menu with form
+	mapMenuToFocusPrmt["menu_query"] = formPrmt
+
 	flex := tview.NewFlex()
 	flex.SetBorderPadding(1, 1, 1, 1)
 	flex.AddItem(formPrmt, 0, 1, false)
 	flex.AddItem(previewFlexPrmt, 0, 2, false)
 
-	// Map menu with form
-	mapMenuToFocusPrmt["menu_body"] = formPrmt
-
 	return flex
 }
 
-func (view *RequestExpertModeView) makePreviewPage() *tview.Flex {
-	titlePrmt := tview.NewTextView()
-	titlePrmt.SetText(view.Labels["requestPreview"])
-	titlePrmt.SetTextColor(tcell.ColorGreen)
-	titlePrmt.
+func (view *RequestExpertModeView) makePathParamsPage(mapMenuToFocusPrmt map[string]tview.Primitive) *tview.Flex {
+	// Display path params preview
+	displayPreview := func(textView *tview.TextView) {
+		makeRequestData := view.AppCtx.GetMDR().Normalized()
+
+		var sb strings.Builder
+		for _, key := range makeRequestData.PathParams.ToSortedKeys() {
+			sb.WriteString("[" + utils.BlueColorName + "]" + key + "[white] " + tview.Escape(makeRequestData.PathParams[key]))
+			sb.WriteString("\r\n\r\n")
+		}
+		url := makeRequestData.URL.ReplaceContext(makeRequestData.PathParams).String()
+		sb.WriteString("[yellow]" + view.Labels["url"] + "[white]: " + tview.Escape(view.MaskSecrets(url)))
+		sb.WriteString("\r\n\r\n")
+		sb.WriteString("[gray]" + tview.Escape(view.Labels["pathParamsHelp"]))
+		textView.SetText(sb.String())
+	}
+
+	// Make preview prmt
+	previewTitlePrmt := tview.NewTextView()
+	previewTitlePrmt.SetText(view.Labels["pathParamsPreview"])
+	previewTitlePrmt.SetTextColor(tcell.ColorGreen)
+	previewTitlePrmt.
 		SetTextAlign(tview.AlignCenter).
 		SetBackgroundColor(utils.BackGrayColor)
 
 	previewPrmt := tview.NewTextView().
 		SetDynamicColors(true).
-		SetScrollable(true).
-		ScrollTo(0, 0)
+		SetScrollable(true)
 	previewPrmt.SetBackgroundColor(utils.BackGrayColor)
-	previewPrmt.Box.SetBorderPadding(1, 1, 1, 1)
+
+	previewFlexPrmt := tview.NewFlex().SetDirection(tview.FlexRow)
+	previewFlexPrmt.AddItem(previewTitlePrmt, 1, 0, false)
+	previewFlexPrmt.AddItem(tview.NewBox().SetBackgroundColor(utils.BackGrayColor), 1, 0, false)
+	previewFlexPrmt.AddItem(previewPrmt, 0, 1, false)
+
+	// Make path params form
+	formPrmt := tview.NewForm()
+	formPrmt.SetBorder(false)
+	formPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	selectedEventDropDown := func(key string) {
+		value := view.AppCtx.GetMDR().Normalized().PathParams[key]
+
+		utils.GetInputFieldForm(formPrmt, view.Labels["key"]).SetText(key)
+		utils.GetInputFieldForm(formPrmt, view.Labels["value"]).SetText(value)
+	}
+
+	saveAndRefreshView := func(makeRequestData models.MakeRequestData) {
+		// update object
+		view.updateMDR(makeRequestData)
+
+		dropDrownPrmt := utils.GetDropDownFieldForm(formPrmt, view.Labels["pathParams"])
+		dropDrownPrmt.SetOptions(makeRequestData.Normalized().PathParams.ToSortedKeys(), func(option string, index int) {
+			selectedEventDropDown(option)
+		})
+		// Very important, fill the component with values before to SetCurrentOption
+		dropDrownPrmt.SetCurrentOption(0)
+
+		displayPreview(previewPrmt)
+	}
+
+	// Add "Path variables" field
+	formPrmt.AddDropDown(view.Labels["pathParams"], nil, 0, func(option string, index int) {
+		selectedEventDropDown(option)
+	})
+
+	// Add "Key" field
+	formPrmt.AddInputField(view.Labels["key"], "", 0, nil, nil)
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["key"])
+
+	// Add "Value" field
+	formPrmt.AddInputField(view.Labels["value"], "", 0, nil, nil)
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["value"])
+
+	// Add "Add" button
+	formPrmt.AddButton(view.Labels["add"], func() {
+		key := models.PathParamName(utils.GetInputFieldForm(formPrmt, view.Labels["key"]).GetText())
+		if key == "" {
+			return
+		}
+
+		makeRequestData := view.AppCtx.GetMDR().Normalized()
+		// add new value
+		makeRequestData.PathParams[key] = utils.GetInputFieldForm(formPrmt, view.Labels["value"]).GetText()
+
+		saveAndRefreshView(makeRequestData)
+	})
+
+	// Add "Remove" button
+	formPrmt.AddButton(view.Labels["remove"], func() {
+		utils.GetInputFieldForm(formPrmt, view.Labels["key"]).SetText("")
+		utils.GetInputFieldForm(formPrmt, view.Labels["value"]).SetText("")
+
+		_, key := utils.GetDropDownFieldForm(formPrmt, view.Labels["pathParams"]).GetCurrentOption()
+
+		makeRequestData := view.AppCtx.GetMDR().Normalized()
+		// delete value
+		delete(makeRequestData.PathParams, key)
+
+		saveAndRefreshView(makeRequestData)
+	})
 
 	// Add listener to refresh primitive when the MakeRequestData is changing...
-	view.AppCtx.AddListenerMRD["requestExpertModeViewPreviewPage"] = func(makeRequestData models.MakeRequestData) {
-		view.displayPreview(previewPrmt, makeRequestData)
+	view.AppCtx.AddListenerMRD["requestExpertModeViewPathParamsPage"] = func(makeRequestData models.MakeRequestData) {
+		utils.GetInputFieldForm(formPrmt, view.Labels["key"]).SetText("")
+		utils.GetInputFieldForm(formPrmt, view.Labels["value"]).SetText("")
+
+		saveAndRefreshView(view.AppCtx.GetMDR())
 	}
 
-	view.displayPreview(previewPrmt, view.AppCtx.GetMDR())
+	// Map menu with form
+	mapMenuToFocusPrmt["menu_path"] = formPrmt
 
-	flexPrmt := tview.NewFlex().SetDirection(tview.FlexRow)
-	flexPrmt.SetBorderPadding(1, 0, 0, 0)
-	flexPrmt.AddItem(titlePrmt, 1, 0, false)
-	flexPrmt.AddItem(tview.NewBox().SetBackgroundColor(utils.BackGrayColor), 1, 0, false)
-	flexPrmt.AddItem(previewPrmt, 0, 1, false)
+	flex := tview.NewFlex()
+	flex.SetBorderPadding(1, 1, 1, 1)
+	flex.AddItem(formPrmt, 0, 1, false)
+	flex.AddItem(previewFlexPrmt, 0, 2, false)
 
-	return flexPrmt
+	return flex
 }
 
-func (view *RequestExpertModeView) displayPreview(textView *tview.TextView, makeRequestData models.MakeRequestData) {
-	textView.SetText("")
-	var sb strings.Builder
+func (view *RequestExpertModeView) makeAddAssertionPage(mapMenuToFocusPrmt map[string]tview.Primitive) *tview.Flex {
+	// Display assertions preview
+	displayPreview := func(textView *tview.TextView) {
+		var sb strings.Builder
+		for _, assertion := range view.AppCtx.GetMDR().Assertions {
+			sb.WriteString("[" + utils.BlueColorName + "]" + assertion.Type + "[white] ")
+			if assertion.Target != "" {
+				sb.WriteString(tview.Escape(assertion.Target) + " ")
+			}
+			sb.WriteString(tview.Escape(assertion.Value))
+			sb.WriteString("\r\n\r\n")
+		}
+		sb.WriteString("[gray]" + tview.Escape(view.Labels["assertionHelp"]))
+		textView.SetText(sb.String())
+	}
 
-	sb.WriteString("[yellow]" + view.Labels["projectName"] + "[white]: " + makeRequestData.ProjectName)
-	sb.WriteString("\r\n")
-	sb.WriteString("[yellow]" + view.Labels["alias"] + "[white]: " + makeRequestData.Alias)
-	sb.WriteString("\r\n\r\n")
+	// Make preview prmt
+	previewTitlePrmt := tview.NewTextView()
+	previewTitlePrmt.SetText(view.Labels["assertionsPreview"])
+	previewTitlePrmt.SetTextColor(tcell.ColorGreen)
+	previewTitlePrmt.
+		SetTextAlign(tview.AlignCenter).
+		SetBackgroundColor(utils.BackGrayColor)
 
-	sb.WriteString("[yellow]" + view.Labels["method"] + "[white]: " + makeRequestData.Method.String())
-	sb.WriteString("\r\n")
-	sb.WriteString("[yellow]" + view.Labels["url"] + "[white]: " + makeRequestData.URL.ReplaceContext(makeRequestData.MapRequestHeaderKeyValue).String())
-	sb.WriteString("\r\n\r\n")
+	previewPrmt := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	previewPrmt.SetBackgroundColor(utils.BackGrayColor)
 
-	sb.WriteString("[yellow]" + view.Labels["contentType"] + "[white]: " + makeRequestData.ContentType)
-	sb.WriteString("\r\n")
-	sb.WriteString("[yellow]" + view.Labels["headers"] + ":\r\n")
-	for k, v := range makeRequestData.MapRequestHeaderKeyValue {
-		sb.WriteString("[" + utils.BlueColorName + "]" + k + "[white] " + v)
-		sb.WriteString("\r\n")
-	}
-	sb.WriteString("\r\n")
+	previewFlexPrmt := tview.NewFlex().SetDirection(tview.FlexRow)
+	previewFlexPrmt.AddItem(previewTitlePrmt, 1, 0, false)
+	previewFlexPrmt.AddItem(tview.NewBox().SetBackgroundColor(utils.BackGrayColor), 1, 0, false)
+	previewFlexPrmt.AddItem(previewPrmt, 0, 1, false)
 
-	sb.WriteString("[yellow]" + view.Labels["body"] + ":")
-	if makeRequestData.Body != "" {
-		sb.WriteString("\r\n")
-		sb.WriteString(makeRequestData.Body)
+	textViewError := tview.NewTextView()
+	textViewError.SetTextColor(tcell.ColorRed)
+	textViewError.SetBackgroundColor(utils.BackGrayColor)
+
+	// Make assertion form
+	formPrmt := tview.NewForm()
+	formPrmt.SetBorder(false)
+	formPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	selectedEventDropDown := func(index int) {
+		assertions := view.AppCtx.GetMDR().Assertions
+		if index < 0 || index >= len(assertions) {
+			return
+		}
+		assertion := assertions[index]
+
+		utils.GetDropDownFieldForm(formPrmt, view.Labels["assertionType"]).SetCurrentOption(models.AssertionTypes.GetIndex(assertion.Type))
+		utils.GetInputFieldForm(formPrmt, view.Labels["assertionTarget"]).SetText(assertion.Target)
+		utils.GetInputFieldForm(formPrmt, view.Labels["assertionValue"]).SetText(assertion.Value)
 	}
-	textView.SetText(sb.String())
-}
 
-func (view *RequestExpertModeView) updateMDR(data models.MakeRequestData) {
-	view.AppCtx.UpdateMDR(data)
-	if update, is := view.AppCtx.AddListenerMRD["requestExpertModeViewPreviewPage"]; is {
-		update(data)
+	saveAndRefreshView := func(makeRequestData models.MakeRequestData) {
+		// update object
+		view.updateMDR(makeRequestData)
+
+		var options []string
+		for _, assertion := range makeRequestData.Assertions {
+			options = append(options, assertion.String())
+		}
+
+		dropDrownPrmt := utils.GetDropDownFieldForm(formPrmt, view.Labels["assertions"])
+		dropDrownPrmt.SetOptions(options, func(option string, index int) {
+			selectedEventDropDown(index)
+		})
+		// Very important, fill the component with values before to SetCurrentOption
+		dropDrownPrmt.SetCurrentOption(0)
+
+		displayPreview(previewPrmt)
+	}
+
+	// Add "Assertions" field
+	formPrmt.AddDropDown(view.Labels["assertions"], nil, 0, func(option string, index int) {
+		selectedEventDropDown(index)
+	})
+
+	// Add "Type" field
+	formPrmt.AddDropDown(view.Labels["assertionType"], models.AssertionTypes, 0, nil)
+
+	// Add "Header / JSONPath" field
+	formPrmt.AddInputField(view.Labels["assertionTarget"], "", 0, nil, nil)
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["assertionTarget"])
+
+	// Add "Expected" field
+	formPrmt.AddInputField(view.Labels["assertionValue"], "", 0, nil, nil)
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["assertionValue"])
+
+	// Add "Add" button
+	formPrmt.AddButton(view.Labels["add"], func() {
+		_, assertionType := utils.GetDropDownFieldForm(formPrmt, view.Labels["assertionType"]).GetCurrentOption()
+		assertion := models.NewAssertion(
+			assertionType,
+			utils.GetInputFieldForm(formPrmt, view.Labels["assertionTarget"]).GetText(),
+			utils.GetInputFieldForm(formPrmt, view.Labels["assertionValue"]).GetText())
+
+		// the context variables can't be validated before the execution
+		if error := assertion.Validate(); error != nil && !strings.Contains(assertion.Target+assertion.Value, "{") {
+			textViewError.SetText(error.Error())
+			return
+		}
+		textViewError.SetText("")
+
+		makeRequestData := view.AppCtx.GetMDR()
+		makeRequestData.Assertions = append(append([]models.Assertion{}, makeRequestData.Assertions...), assertion)
+
+		saveAndRefreshView(makeRequestData)
+	})
+
+	// Add "Remove" button
+	formPrmt.AddButton(view.Labels["remove"], func() {
+		utils.GetInputFieldForm(formPrmt, view.Labels["assertionTarget"]).SetText("")
+		utils.GetInputFieldForm(formPrmt, view.Labels["assertionValue"]).SetText("")
+		textViewError.SetText("")
+
+		index, _ := utils.GetDropDownFieldForm(formPrmt, view.Labels["assertions"]).GetCurrentOption()
+
+		makeRequestData := view.AppCtx.GetMDR()
+		if index < 0 || index >= len(makeRequestData.Assertions) {
+			return
+		}
+		// delete value
+		assertions := append([]models.Assertion{}, makeRequestData.Assertions[:index]...)
+		makeRequestData.Assertions = append(assertions, makeRequestData.Assertions[index+1:]...)
+
+		saveAndRefreshView(makeRequestData)
+	})
+
+	// Add listener to refresh primitive when the MakeRequestData is changing...
+	view.AppCtx.AddListenerMRD["requestExpertModeViewAssertionPage"] = func(makeRequestData models.MakeRequestData) {
+		utils.GetInputFieldForm(formPrmt, view.Labels["assertionTarget"]).SetText("")
+		utils.GetInputFieldForm(formPrmt, view.Labels["assertionValue"]).SetText("")
+		textViewError.SetText("")
+
+		saveAndRefreshView(view.AppCtx.GetMDR())
+	}
+
+	// Map menu with form
+	mapMenuToFocusPrmt["menu_assertion"] = formPrmt
+
+	formFlexPrmt := tview.NewFlex().SetDirection(tview.FlexRow)
+	formFlexPrmt.AddItem(formPrmt, 0, 1, false)
+	formFlexPrmt.AddItem(textViewError, 1, 0, false)
+
+	flex := tview.NewFlex()
+	flex.SetBorderPadding(1, 1, 1, 1)
+	flex.AddItem(formFlexPrmt, 0, 1, false)
+	flex.AddItem(previewFlexPrmt, 0, 2, false)
+
+	return flex
+}
+
+func (view *RequestExpertModeView) makeAddExtractionPage(mapMenuToFocusPrmt map[string]tview.Primitive) *tview.Flex {
+	// Display extractions preview
+	displayPreview := func(textView *tview.TextView) {
+		var sb strings.Builder
+		for _, extraction := range view.AppCtx.GetMDR().Extractions {
+			sb.WriteString("[" + utils.BlueColorName + "]" + extraction.Source + "[white] " + tview.Escape(extraction.Expression))
+			sb.WriteString(" => [" + utils.BlueColorName + "]" + extraction.Variable)
+			sb.WriteString("\r\n\r\n")
+		}
+		sb.WriteString("[gray]" + tview.Escape(view.Labels["extractionHelp"]))
+		textView.SetText(sb.String())
+	}
+
+	// Make preview prmt
+	previewTitlePrmt := tview.NewTextView()
+	previewTitlePrmt.SetText(view.Labels["extractionsPreview"])
+	previewTitlePrmt.SetTextColor(tcell.ColorGreen)
+	previewTitlePrmt.
+		SetTextAlign(tview.AlignCenter).
+		SetBackgroundColor(utils.BackGrayColor)
+
+	previewPrmt := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	previewPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	previewFlexPrmt := tview.NewFlex().SetDirection(tview.FlexRow)
+	previewFlexPrmt.AddItem(previewTitlePrmt, 1, 0, false)
+	previewFlexPrmt.AddItem(tview.NewBox().SetBackgroundColor(utils.BackGrayColor), 1, 0, false)
+	previewFlexPrmt.AddItem(previewPrmt, 0, 1, false)
+
+	textViewError := tview.NewTextView()
+	textViewError.SetTextColor(tcell.ColorRed)
+	textViewError.SetBackgroundColor(utils.BackGrayColor)
+
+	// Make extraction form
+	formPrmt := tview.NewForm()
+	formPrmt.SetBorder(false)
+	formPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	selectedEventDropDown := func(index int) {
+		extractions := view.AppCtx.GetMDR().Extractions
+		if index < 0 || index >= len(extractions) {
+			return
+		}
+		extraction := extractions[index]
+
+		utils.GetDropDownFieldForm(formPrmt, view.Labels["extractionSource"]).SetCurrentOption(models.ExtractionSources.GetIndex(extraction.Source))
+		utils.GetInputFieldForm(formPrmt, view.Labels["extractionExpression"]).SetText(extraction.Expression)
+		utils.GetInputFieldForm(formPrmt, view.Labels["extractionVariable"]).SetText(extraction.Variable)
+	}
+
+	saveAndRefreshView := func(makeRequestData models.MakeRequestData) {
+		// update object
+		view.updateMDR(makeRequestData)
+
+		var options []string
+		for _, extraction := range makeRequestData.Extractions {
+			options = append(options, extraction.String())
+		}
+
+		dropDrownPrmt := utils.GetDropDownFieldForm(formPrmt, view.Labels["extractions"])
+		dropDrownPrmt.SetOptions(options, func(option string, index int) {
+			selectedEventDropDown(index)
+		})
+		// Very important, fill the component with values before to SetCurrentOption
+		dropDrownPrmt.SetCurrentOption(0)
+
+		displayPreview(previewPrmt)
+	}
+
+	// Add "Extractions" field
+	formPrmt.AddDropDown(view.Labels["extractions"], nil, 0, func(option string, index int) {
+		selectedEventDropDown(index)
+	})
+
+	// Add "Source" field
+	formPrmt.AddDropDown(view.Labels["extractionSource"], models.ExtractionSources, 0, nil)
+
+	// Add "Expression" field
+	formPrmt.AddInputField(view.Labels["extractionExpression"], "", 0, nil, nil)
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["extractionExpression"])
+
+	// Add "Variable" field
+	formPrmt.AddInputField(view.Labels["extractionVariable"], "", 0, nil, nil)
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["extractionVariable"])
+
+	// Add "Add" button
+	formPrmt.AddButton(view.Labels["add"], func() {
+		_, source := utils.GetDropDownFieldForm(formPrmt, view.Labels["extractionSource"]).GetCurrentOption()
+		extraction := models.NewExtraction(
+			source,
+			utils.GetInputFieldForm(formPrmt, view.Labels["extractionExpression"]).GetText(),
+			utils.GetInputFieldForm(formPrmt, view.Labels["extractionVariable"]).GetText())
+
+		if error := extraction.Validate(); error != nil {
+			textViewError.SetText(error.Error())
+			return
+		}
+		textViewError.SetText("")
+
+		makeRequestData := view.AppCtx.GetMDR()
+
+		// replace the extraction of the same variable
+		extractions := []models.Extraction{}
+		for _, value := range makeRequestData.Extractions {
+			if value.Variable != extraction.Variable {
+				extractions = append(extractions, value)
+			}
+		}
+		makeRequestData.Extractions = append(extractions, extraction)
+
+		saveAndRefreshView(makeRequestData)
+	})
+
+	// Add "Remove" button
+	formPrmt.AddButton(view.Labels["remove"], func() {
+		utils.GetInputFieldForm(formPrmt, view.Labels["extractionExpression"]).SetText("")
+		utils.GetInputFieldForm(formPrmt, view.Labels["extractionVariable"]).SetText("")
+		textViewError.SetText("")
+
+		index, _ := utils.GetDropDownFieldForm(formPrmt, view.Labels["extractions"]).GetCurrentOption()
+
+		makeRequestData := view.AppCtx.GetMDR()
+		if index < 0 || index >= len(makeRequestData.Extractions) {
+			return
+		}
+		// delete value
+		extractions := append([]models.Extraction{}, makeRequestData.Extractions[:index]...)
+		makeRequestData.Extractions = append(extractions, makeRequestData.Extractions[index+1:]...)
+
+		saveAndRefreshView(makeRequestData)
+	})
+
+	// Add listener to refresh primitive when the MakeRequestData is changing...
+	view.AppCtx.AddListenerMRD["requestExpertModeViewExtractionPage"] = func(makeRequestData models.MakeRequestData) {
+		utils.GetInputFieldForm(formPrmt, view.Labels["extractionExpression"]).SetText("")
+		utils.GetInputFieldForm(formPrmt, view.Labels["extractionVariable"]).SetText("")
+		textViewError.SetText("")
+
+		saveAndRefreshView(view.AppCtx.GetMDR())
+	}
+
+	// Map menu with form
+	mapMenuToFocusPrmt["menu_extraction"] = formPrmt
+
+	formFlexPrmt := tview.NewFlex().SetDirection(tview.FlexRow)
+	formFlexPrmt.AddItem(formPrmt, 0, 1, false)
+	formFlexPrmt.AddItem(textViewError, 1, 0, false)
+
+	flex := tview.NewFlex()
+	flex.SetBorderPadding(1, 1, 1, 1)
+	flex.AddItem(formFlexPrmt, 0, 1, false)
+	flex.AddItem(previewFlexPrmt, 0, 2, false)
+
+	return flex
+}
+
+func (view *RequestExpertModeView) makeAddBodyPage(mapMenuToFocusPrmt map[string]tview.Primitive) *tview.Flex {
+	// Make preview prmt
+	previewTitlePrmt := tview.NewTextView()
+	previewTitlePrmt.SetText(view.Labels["bodyPreview"])
+	previewTitlePrmt.SetTextColor(tcell.ColorGreen)
+	previewTitlePrmt.
+		SetTextAlign(tview.AlignCenter).
+		SetBackgroundColor(utils.BackGrayColor)
+
+	previewPrmt := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	previewPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	previewFlexPrmt := tview.NewFlex().SetDirection(tview.FlexRow)
+	previewFlexPrmt.AddItem(previewTitlePrmt, 1, 0, false)
+	previewFlexPrmt.AddItem(tview.NewBox().SetBackgroundColor(utils.BackGrayColor), 1, 0, false)
+	previewFlexPrmt.AddItem(previewPrmt, 0, 1, false)
+
+	formPrmt := tview.NewForm()
+	formPrmt.SetBorder(false)
+	formPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	// Add "Body" field
+	formPrmt.AddInputField(view.Labels["body"], "", 0, nil, func(value string) {
+		previewPrmt.SetText(value)
+	})
+
+	// Add generic events to inputField
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["body"])
+
+	// Add "Add" button
+	formPrmt.AddButton(view.Labels["add"], func() {
+		makeRequestData := view.AppCtx.GetMDR()
+		makeRequestData.Body = utils.GetInputFieldForm(formPrmt, view.Labels["body"]).GetText()
+
+		// Update request
+		view.updateMDR(makeRequestData)
+		previewPrmt.SetText(makeRequestData.Body)
+	})
+
+	// Add listener to refresh primitive when the MakeRequestData is changing...
+	view.AppCtx.AddListenerMRD["requestExpertModeViewBodyPage"] = func(makeRequestData models.MakeRequestData) {
+		utils.GetInputFieldForm(formPrmt, view.Labels["body"]).SetText(makeRequestData.Body)
+		previewPrmt.SetText(makeRequestData.Body)
+	}
+
+	flex := tview.NewFlex()
+	flex.SetBorderPadding(1, 1, 1, 1)
+	flex.AddItem(formPrmt, 0, 1, false)
+	flex.AddItem(previewFlexPrmt, 0, 2, false)
+
+	// Map menu with form
+	mapMenuToFocusPrmt["menu_body"] = formPrmt
+
+	return flex
+}
+
+func (view *RequestExpertModeView) makeExportPage(mapMenuToFocusPrmt map[string]tview.Primitive) *tview.Flex {
+	// Make preview prmt
+	previewTitlePrmt := tview.NewTextView()
+	previewTitlePrmt.SetText(view.Labels["exportPreview"])
+	previewTitlePrmt.SetTextColor(tcell.ColorGreen)
+	previewTitlePrmt.
+		SetTextAlign(tview.AlignCenter).
+		SetBackgroundColor(utils.BackGrayColor)
+
+	previewPrmt := tview.NewTextView().
+		SetScrollable(true)
+	previewPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	textViewStatus := tview.NewTextView()
+	textViewStatus.SetTextColor(tcell.ColorGreen)
+	textViewStatus.SetBackgroundColor(utils.BackGrayColor)
+
+	previewFlexPrmt := tview.NewFlex().SetDirection(tview.FlexRow)
+	previewFlexPrmt.AddItem(previewTitlePrmt, 1, 0, false)
+	previewFlexPrmt.AddItem(tview.NewBox().SetBackgroundColor(utils.BackGrayColor), 1, 0, false)
+	previewFlexPrmt.AddItem(previewPrmt, 0, 1, false)
+	previewFlexPrmt.AddItem(textViewStatus, 1, 0, false)
+
+	formPrmt := tview.NewForm()
+	formPrmt.SetBorder(false)
+	formPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	// makeSnippet builds the snippet of the current request for the selected format & execution context
+	makeSnippet := func() string {
+		_, env := utils.GetDropDownFieldForm(formPrmt, view.Labels["context"]).GetCurrentOption()
+		_, format := utils.GetDropDownFieldForm(formPrmt, view.Labels["format"]).GetCurrentOption()
+
+		return converters.ToSnippet(format, view.ResolveSnippet(view.AppCtx.GetMDR(), env))
+	}
+
+	refreshPreview := func() {
+		// the form is not completely built yet
+		if formPrmt.GetFormItemCount() < 2 {
+			return
+		}
+		textViewStatus.SetText("")
+		previewPrmt.SetText(makeSnippet()).ScrollToBeginning()
+	}
+
+	// Add "Execution Context" field
+	formPrmt.AddDropDown(view.Labels["context"], nil, 0, nil)
+
+	// Add "Format" field
+	formPrmt.AddDropDown(view.Labels["format"], converters.SnippetFormats, 0, func(option string, index int) {
+		refreshPreview()
+	})
+
+	// Add "Copy" button
+	formPrmt.AddButton(view.Labels["copy"], func() {
+		textViewStatus.SetText(view.Labels["copied"])
+		utils.WriteToClipboard(makeSnippet(), func(message string, event string) {
+			textViewStatus.SetText(message)
+		})
+	})
+
+	// Add listener to refresh primitive when the context is changing...
+	view.AppCtx.AddContextListener["requestExpertModeViewExportPage"] = func(context models.Context) {
+		envs := context.GetEnvsName()
+
+		prmt := utils.GetDropDownFieldForm(formPrmt, view.Labels["context"])
+		prmt.SetOptions(envs, func(option string, index int) {
+			refreshPreview()
+		})
+		prmt.SetCurrentOption(envs.GetIndex("default"))
+	}
+
+	// Add listener to refresh primitive when the MakeRequestData is changing...
+	view.AppCtx.AddListenerMRD["requestExpertModeViewExportPage"] = func(makeRequestData models.MakeRequestData) {
+		refreshPreview()
+	}
+
+	flex := tview.NewFlex()
+	flex.SetBorderPadding(1, 1, 1, 1)
+	flex.AddItem(formPrmt, 0, 1, false)
+	flex.AddItem(previewFlexPrmt, 0, 2, false)
+
+	// Map menu with form
+	mapMenuToFocusPrmt["menu_export"] = formPrmt
+
+	return flex
+}
+
+func (view *RequestExpertModeView) makeTimeoutPage(mapMenuToFocusPrmt map[string]tview.Primitive) *tview.Flex {
+	// Display timeouts preview (request values or default values from settings)
+	displayPreview := func(textView *tview.TextView, timeout models.Timeout) {
+		format := func(label string, value string, defaultValue string) string {
+			if value == "" {
+				return "[" + utils.BlueColorName + "]" + label + "[white] " + defaultValue + " " + view.Labels["timeoutDefault"]
+			}
+			return "[" + utils.BlueColorName + "]" + label + "[white] " + value
+		}
+		defaults := view.AppCtx.GetConfig().Timeout.Merge(models.Timeout{Connect: models.DefaultConnectTimeout, Total: models.DefaultTotalTimeout})
+
+		var sb strings.Builder
+		sb.WriteString(format(view.Labels["connectTimeout"], timeout.Connect, defaults.Connect))
+		sb.WriteString("\r\n\r\n")
+		sb.WriteString(format(view.Labels["totalTimeout"], timeout.Total, defaults.Total))
+		textView.SetText(sb.String())
+	}
+
+	// Make preview prmt
+	previewTitlePrmt := tview.NewTextView()
+	previewTitlePrmt.SetText(view.Labels["timeoutPreview"])
+	previewTitlePrmt.SetTextColor(tcell.ColorGreen)
+	previewTitlePrmt.
+		SetTextAlign(tview.AlignCenter).
+		SetBackgroundColor(utils.BackGrayColor)
+
+	previewPrmt := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	previewPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	textViewError := tview.NewTextView()
+	textViewError.SetTextColor(tcell.ColorRed)
+	textViewError.SetBackgroundColor(utils.BackGrayColor)
+
+	previewFlexPrmt := tview.NewFlex().SetDirection(tview.FlexRow)
+	previewFlexPrmt.AddItem(previewTitlePrmt, 1, 0, false)
+	previewFlexPrmt.AddItem(tview.NewBox().SetBackgroundColor(utils.BackGrayColor), 1, 0, false)
+	previewFlexPrmt.AddItem(previewPrmt, 0, 1, false)
+	previewFlexPrmt.AddItem(textViewError, 1, 0, false)
+
+	formPrmt := tview.NewForm()
+	formPrmt.SetBorder(false)
+	formPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	// Add "Connect timeout" & "Total timeout" fields
+	formPrmt.AddInputField(view.Labels["connectTimeout"], "", 0, nil, nil)
+	formPrmt.AddInputField(view.Labels["totalTimeout"], "", 0, nil, nil)
+
+	// Add generic events to inputField
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["connectTimeout"])
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["totalTimeout"])
+
+	// Add "Save" button
+	formPrmt.AddButton(view.Labels["save"], func() {
+		timeout := models.Timeout{
+			Connect: utils.GetInputFieldForm(formPrmt, view.Labels["connectTimeout"]).GetText(),
+			Total:   utils.GetInputFieldForm(formPrmt, view.Labels["totalTimeout"]).GetText(),
+		}
+		if error := timeout.Validate(); error != nil {
+			textViewError.SetText(error.Error())
+			return
+		}
+		textViewError.SetText("")
+
+		makeRequestData := view.AppCtx.GetMDR()
+		makeRequestData.Timeout = timeout
+
+		// Update request
+		view.updateMDR(makeRequestData)
+		displayPreview(previewPrmt, makeRequestData.Timeout)
+	})
+
+	// Add listener to refresh primitive when the MakeRequestData is changing...
+	view.AppCtx.AddListenerMRD["requestExpertModeViewTimeoutPage"] = func(makeRequestData models.MakeRequestData) {
+		utils.GetInputFieldForm(formPrmt, view.Labels["connectTimeout"]).SetText(makeRequestData.Timeout.Connect)
+		utils.GetInputFieldForm(formPrmt, view.Labels["totalTimeout"]).SetText(makeRequestData.Timeout.Total)
+		textViewError.SetText("")
+		displayPreview(previewPrmt, makeRequestData.Timeout)
+	}
+
+	flex := tview.NewFlex()
+	flex.SetBorderPadding(1, 1, 1, 1)
+	flex.AddItem(formPrmt, 0, 1, false)
+	flex.AddItem(previewFlexPrmt, 0, 2, false)
+
+	// Map menu with form
+	mapMenuToFocusPrmt["menu_timeout"] = formPrmt
+
+	return flex
+}
+
+func (view *RequestExpertModeView) makeAuthPage(mapMenuToFocusPrmt map[string]tview.Primitive) *tview.Flex {
+	// Display the authentication of the request & the help
+	displayPreview := func(textView *tview.TextView, auth models.Auth) {
+		var sb strings.Builder
+		sb.WriteString("[" + utils.BlueColorName + "]" + view.Labels["auth"] + "[white] " + tview.Escape(view.authLabel(auth)))
+		sb.WriteString("\r\n\r\n")
+		sb.WriteString("[gray]" + tview.Escape(view.Labels["authHelp"]))
+		textView.SetText(sb.String())
+	}
+
+	// Make preview prmt
+	previewTitlePrmt := tview.NewTextView()
+	previewTitlePrmt.SetText(view.Labels["authPreview"])
+	previewTitlePrmt.SetTextColor(tcell.ColorGreen)
+	previewTitlePrmt.
+		SetTextAlign(tview.AlignCenter).
+		SetBackgroundColor(utils.BackGrayColor)
+
+	previewPrmt := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	previewPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	textViewError := tview.NewTextView()
+	textViewError.SetTextColor(tcell.ColorRed)
+	textViewError.SetBackgroundColor(utils.BackGrayColor)
+
+	previewFlexPrmt := tview.NewFlex().SetDirection(tview.FlexRow)
+	previewFlexPrmt.AddItem(previewTitlePrmt, 1, 0, false)
+	previewFlexPrmt.AddItem(tview.NewBox().SetBackgroundColor(utils.BackGrayColor), 1, 0, false)
+	previewFlexPrmt.AddItem(previewPrmt, 0, 1, false)
+	previewFlexPrmt.AddItem(textViewError, 1, 0, false)
+
+	formPrmt := tview.NewForm()
+	formPrmt.SetBorder(false)
+	formPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	// Add "Type", "Username", "Password", "Token / Value" & "Key name" fields
+	var authTypeLabels []string
+	for _, authType := range models.AuthTypes {
+		authTypeLabels = append(authTypeLabels, view.Labels[authType])
+	}
+	formPrmt.AddDropDown(view.Labels["authType"], authTypeLabels, 0, nil)
+	formPrmt.AddInputField(view.Labels["authUsername"], "", 0, nil, nil)
+	formPrmt.AddPasswordField(view.Labels["authPassword"], "", 0, '*', nil)
+	formPrmt.AddInputField(view.Labels["authToken"], "", 0, nil, nil)
+	formPrmt.AddInputField(view.Labels["authKey"], "", 0, nil, nil)
+
+	// Add generic events to inputField
+	for _, label := range []string{"authUsername", "authPassword", "authToken", "authKey"} {
+		utils.AddInputFieldEventForm(formPrmt, view.Labels[label])
+	}
+
+	// Add "Save" button
+	formPrmt.AddButton(view.Labels["save"], func() {
+		index, _ := utils.GetDropDownFieldForm(formPrmt, view.Labels["authType"]).GetCurrentOption()
+		auth := models.Auth{
+			Type:     models.AuthTypes[index],
+			Username: utils.GetInputFieldForm(formPrmt, view.Labels["authUsername"]).GetText(),
+			Password: utils.GetInputFieldForm(formPrmt, view.Labels["authPassword"]).GetText(),
+			Token:    utils.GetInputFieldForm(formPrmt, view.Labels["authToken"]).GetText(),
+			Key:      utils.GetInputFieldForm(formPrmt, view.Labels["authKey"]).GetText(),
+		}
+		if error := auth.Validate(); error != nil {
+			textViewError.SetText(error.Error())
+			return
+		}
+		textViewError.SetText("")
+
+		if auth.IsEmpty() {
+			auth = models.Auth{}
+		}
+		makeRequestData := view.AppCtx.GetMDR()
+		makeRequestData.Auth = auth
+
+		// Update request
+		view.updateMDR(makeRequestData)
+		displayPreview(previewPrmt, makeRequestData.Auth)
+	})
+
+	// Add listener to refresh primitive when the MakeRequestData is changing...
+	view.AppCtx.AddListenerMRD["requestExpertModeViewAuthPage"] = func(makeRequestData models.MakeRequestData) {
+		auth := makeRequestData.Auth
+		index := models.AuthTypes.GetIndex(auth.Type)
+		if index == -1 {
+			index = 0
+		}
+		utils.GetDropDownFieldForm(formPrmt, view.Labels["authType"]).SetCurrentOption(index)
+		utils.GetInputFieldForm(formPrmt, view.Labels["authUsername"]).SetText(auth.Username)
+		utils.GetInputFieldForm(formPrmt, view.Labels["authPassword"]).SetText(auth.Password)
+		utils.GetInputFieldForm(formPrmt, view.Labels["authToken"]).SetText(auth.Token)
+		utils.GetInputFieldForm(formPrmt, view.Labels["authKey"]).SetText(auth.Key)
+		textViewError.SetText("")
+		displayPreview(previewPrmt, auth)
+	}
+
+	flex := tview.NewFlex()
+	flex.SetBorderPadding(1, 1, 1, 1)
+	flex.AddItem(formPrmt, 0, 1, false)
+	flex.AddItem(previewFlexPrmt, 0, 2, false)
+
+	// Map menu with form
+	mapMenuToFocusPrmt["menu_auth"] = formPrmt
+
+	return flex
+}
+
+// authLabel returns the authentication as a readable string (type label & username or key name, without the secret)
+func (view *RequestExpertModeView) authLabel(auth models.Auth) string {
+	if auth.IsEmpty() {
+		return view.Labels[models.AuthNone]
+	}
+	label, exists := view.Labels[auth.Type]
+	if !exists {
+		label = auth.Type
+	}
+	if principal := auth.Principal(); principal != "" {
+		return label + " " + principal
+	}
+	return label
+}
+
+func (view *RequestExpertModeView) makePreviewPage() *tview.Flex {
+	titlePrmt := tview.NewTextView()
+	titlePrmt.SetText(view.Labels["requestPreview"])
+	titlePrmt.SetTextColor(tcell.ColorGreen)
+	titlePrmt.
+		SetTextAlign(tview.AlignCenter).
+		SetBackgroundColor(utils.BackGrayColor)
+
+	previewPrmt := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		ScrollTo(0, 0)
+	previewPrmt.SetBackgroundColor(utils.BackGrayColor)
+	previewPrmt.Box.SetBorderPadding(1, 1, 1, 1)
+
+	// Add listener to refresh primitive when the MakeRequestData is changing...
+	view.AppCtx.AddListenerMRD["requestExpertModeViewPreviewPage"] = func(makeRequestData models.MakeRequestData) {
+		view.displayPreview(previewPrmt, makeRequestData)
+	}
+
+	view.displayPreview(previewPrmt, view.AppCtx.GetMDR())
+
+	flexPrmt := tview.NewFlex().SetDirection(tview.FlexRow)
+	flexPrmt.SetBorderPadding(1, 0, 0, 0)
+	flexPrmt.AddItem(titlePrmt, 1, 0, false)
+	flexPrmt.AddItem(tview.NewBox().SetBackgroundColor(utils.BackGrayColor), 1, 0, false)
+	flexPrmt.AddItem(previewPrmt, 0, 1, false)
+
+	return flexPrmt
+}
+
+func (view *RequestExpertModeView) displayPreview(textView *tview.TextView, makeRequestData models.MakeRequestData) {
+	textView.SetText("")
+	makeRequestData = makeRequestData.Normalized()
+	var sb strings.Builder
+
+	sb.WriteString("[yellow]" + view.Labels["projectName"] + "[white]: " + makeRequestData.ProjectName)
+	sb.WriteString("\r\n")
+	sb.WriteString("[yellow]" + view.Labels["alias"] + "[white]: " + makeRequestData.Alias)
+	sb.WriteString("\r\n\r\n")
+
+	sb.WriteString("[yellow]" + view.Labels["method"] + "[white]: " + makeRequestData.Method.String())
+	sb.WriteString("\r\n")
+	sb.WriteString("[yellow]" + view.Labels["url"] + "[white]: " + view.MaskSecrets(makeRequestData.URL.ReplaceContext(makeRequestData.PathParams).String()))
+	sb.WriteString("\r\n\r\n")
+
+	if len(makeRequestData.PathParams) > 0 {
+		sb.WriteString("[yellow]" + view.Labels["pathParams"] + ":\r\n")
+		for _, key := range makeRequestData.PathParams.ToSortedKeys() {
+			sb.WriteString("[" + utils.BlueColorName + "]" + key + "[white] " + view.MaskSecrets(makeRequestData.PathParams[key]))
+			sb.WriteString("\r\n")
+		}
+		sb.WriteString("\r\n")
+	}
+
+	if len(makeRequestData.QueryParams) > 0 {
+		sb.WriteString("[yellow]" + view.Labels["queryParams"] + ":\r\n")
+		for _, param := range makeRequestData.QueryParams {
+			sb.WriteString("[white]" + tview.Escape(checkedText(param.Enabled)) + " ")
+			sb.WriteString("[" + utils.BlueColorName + "]" + tview.Escape(param.Key) + "[white] " + tview.Escape(view.MaskSecrets(param.Value)))
+			sb.WriteString("\r\n")
+		}
+		sb.WriteString("\r\n")
+	}
+
+	sb.WriteString("[yellow]" + view.Labels["contentType"] + "[white]: " + makeRequestData.ContentType)
+	sb.WriteString("\r\n")
+	sb.WriteString("[yellow]" + view.Labels["auth"] + "[white]: " + tview.Escape(view.authLabel(makeRequestData.Auth)))
+	sb.WriteString("\r\n")
+	sb.WriteString("[yellow]" + view.Labels["headers"] + ":\r\n")
+	for k, v := range makeRequestData.GetHeaders() {
+		sb.WriteString("[white]" + tview.Escape(checkedText(makeRequestData.IsHeaderEnabled(k))) + " ")
+		sb.WriteString("[" + utils.BlueColorName + "]" + k + "[white] " + view.MaskSecrets(v))
+		sb.WriteString("\r\n")
+	}
+	sb.WriteString("\r\n")
+
+	timeout := makeRequestData.Timeout.Merge(view.AppCtx.GetConfig().Timeout)
+	sb.WriteString("[yellow]" + view.Labels["connectTimeout"] + "[white]: " + timeout.ConnectDuration().String())
+	sb.WriteString("\r\n")
+	sb.WriteString("[yellow]" + view.Labels["totalTimeout"] + "[white]: " + timeout.TotalDuration().String())
+	sb.WriteString("\r\n\r\n")
+
+	if len(makeRequestData.Extractions) > 0 {
+		sb.WriteString("[yellow]" + view.Labels["extractions"] + ":\r\n")
+		for _, extraction := range makeRequestData.Extractions {
+			sb.WriteString("[white]" + tview.Escape(extraction.String()) + "\r\n")
+		}
+		sb.WriteString("\r\n")
+	}
+
+	if len(makeRequestData.Assertions) > 0 {
+		sb.WriteString("[yellow]" + view.Labels["assertions"] + ":\r\n")
+		for _, assertion := range makeRequestData.Assertions {
+			sb.WriteString("[white]" + tview.Escape(assertion.String()) + "\r\n")
+		}
+		sb.WriteString("\r\n")
+	}
+
+	sb.WriteString("[yellow]" + view.Labels["body"] + ":")
+	if makeRequestData.Body != "" {
+		sb.WriteString("\r\n")
+		sb.WriteString(view.MaskSecrets(makeRequestData.Body))
+	}
+	textView.SetText(sb.String())
+}
+
+func (view *RequestExpertModeView) updateMDR(data models.MakeRequestData) {
+	view.AppCtx.UpdateMDR(data)
+	for _, key := range []string{"requestExpertModeViewPreviewPage", "requestExpertModeViewExportPage", "refreshRequestPanelView"} {
+		if update, is := view.AppCtx.AddListenerMRD[key]; is {
+			update(data)
+		}
+	}
+}
+
+// checkedText returns the "[x]" (or "[ ]") text of the @checked state
+func checkedText(checked bool) string {
+	if checked {
+		return "[x]"
 	}
+	return "[ ]"
 }
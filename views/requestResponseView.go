@@ -1,8 +1,15 @@
 package views
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/gdamore/tcell/v2"
+	"github.com/joakim-ribier/gttp/core"
 	"github.com/joakim-ribier/gttp/httpclient"
 	"github.com/joakim-ribier/gttp/models"
 	"github.com/joakim-ribier/gttp/utils"
@@ -17,30 +24,69 @@ type RequestResponseView struct {
 	Labels    map[string]string
 	LogBuffer string
 
-	TitlePrmt    *tview.Flex
-	ParentPrmt   tview.Primitive
-	RequestPrmt  *tview.TextView
-	ResponsePrmt *tview.TextView
+	progressFrame int
+
+	// body of the response displayed in the log buffer (raw or pretty-printed, filtered)
+	body        string
+	contentType string
+	bodyStart   int
+	bodyEnd     int
+	pretty      bool
+	filter      string
+
+	TitlePrmt       *tview.Flex
+	ParentPrmt      tview.Primitive
+	RequestPrmt     *tview.TextView
+	HeadersPrmt     *tview.Table
+	TimingPrmt      *tview.TextView
+	ResponsePrmt    *tview.TextView
+	FilterPrmt      *tview.InputField
+	AssertionsPrmt  *tview.TextView
+	ExtractionsPrmt *tview.TextView
+
+	// Actions
+	SaveFilter func(expression string)
 }
 
 // NewRequestResponseView returns the view for the request response view
-func NewRequestResponseView(app *tview.Application, ev *models.AppCtx) *RequestResponseView {
+func NewRequestResponseView(app *tview.Application, ev *models.AppCtx, saveFilter func(expression string)) *RequestResponseView {
 	labels := make(map[string]string)
 	labels["title"] = "Execute Request"
 	labels["http"] = "HTTP"
 	labels["contentType"] = "Content-Type"
+	labels["proxy"] = "Proxy"
 	labels["host"] = "Host"
-	labels["contentLength"] = "Content-Length"
-	labels["date"] = "Date"
-	labels["referrerPolicy"] = "Referrer-Policy"
-	labels["connection"] = "Connection"
+	labels["headers"] = "Response headers"
+	labels["noHeader"] = "No response header"
 	labels["status"] = "Status"
+	labels["progress"] = "Executing request..."
+	labels["cancelled"] = "Request cancelled after"
+	labels["assertions"] = "Assertions"
+	labels["passed"] = "PASS"
+	labels["failed"] = "FAIL"
+	labels["extractions"] = "Extracted variables"
+	labels["tlsConnection"] = "TLS"
+	labels["certificates"] = "Certificate chain"
+	labels["issuer"] = "issuer"
+	labels["expires"] = "expires"
+	labels["timing"] = "Timing"
+	labels["dns"] = "DNS lookup"
+	labels["connect"] = "TCP connect"
+	labels["tls"] = "TLS handshake"
+	labels["ttfb"] = "Time to first byte"
+	labels["transfer"] = "Content transfer"
+	labels["total"] = "Total"
+	labels["reused"] = "(connection reused)"
+	labels["filter"] = "Filter (jq/JSONPath)"
+	labels["filterPlaceholder"] = ".items[].id or $.items[*].id"
 
 	return &RequestResponseView{
-		App:       app,
-		AppCtx:    ev,
-		Labels:    labels,
-		LogBuffer: "",
+		App:        app,
+		AppCtx:     ev,
+		Labels:     labels,
+		LogBuffer:  "",
+		pretty:     true,
+		SaveFilter: saveFilter,
 	}
 }
 
@@ -49,20 +95,82 @@ func (view *RequestResponseView) InitView() {
 	view.ResponsePrmt = tview.NewTextView()
 	view.ResponsePrmt.SetBackgroundColor(utils.BackGrayColor)
 	view.ResponsePrmt.SetDynamicColors(true).SetScrollable(true)
+	view.ResponsePrmt.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == '/' {
+			view.App.SetFocus(view.FilterPrmt)
+			return nil
+		}
+		return event
+	})
+
+	view.FilterPrmt = tview.NewInputField().
+		SetLabel(view.Labels["filter"] + " ").
+		SetPlaceholder(view.Labels["filterPlaceholder"])
+	view.FilterPrmt.SetBackgroundColor(utils.BackGrayColor)
+	view.FilterPrmt.SetFieldBackgroundColor(utils.BackColor)
+	view.FilterPrmt.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			view.ApplyFilter(view.FilterPrmt.GetText())
+			view.SaveFilter(view.filter)
+		}
+		view.App.SetFocus(view.ResponsePrmt)
+	})
 
 	view.RequestPrmt = tview.NewTextView()
 	view.RequestPrmt.SetBackgroundColor(utils.BackGrayColor).SetBorderPadding(0, 0, 0, 0)
 	view.RequestPrmt.SetDynamicColors(true).SetScrollable(true).SetWrap(true)
 
+	view.HeadersPrmt = tview.NewTable().SetBorders(false).SetSelectable(true, false)
+	view.HeadersPrmt.SetBackgroundColor(utils.BackGrayColor)
+	view.HeadersPrmt.SetFixed(1, 0)
+
+	view.TimingPrmt = tview.NewTextView()
+	view.TimingPrmt.SetBackgroundColor(utils.BackGrayColor)
+	view.TimingPrmt.SetDynamicColors(true).SetWrap(false)
+
+	view.AssertionsPrmt = tview.NewTextView()
+	view.AssertionsPrmt.SetBackgroundColor(utils.BackGrayColor)
+	view.AssertionsPrmt.SetDynamicColors(true).SetScrollable(true).SetWrap(true)
+
+	view.ExtractionsPrmt = tview.NewTextView()
+	view.ExtractionsPrmt.SetBackgroundColor(utils.BackGrayColor)
+	view.ExtractionsPrmt.SetDynamicColors(true).SetScrollable(true).SetWrap(true)
+
 	view.TitlePrmt = utils.MakeTitlePrmt(view.Labels["title"])
 	view.TitlePrmt.AddItem(view.RequestPrmt, 0, 1, false)
+	view.TitlePrmt.AddItem(view.HeadersPrmt, 0, 1, false)
+	view.TitlePrmt.AddItem(view.TimingPrmt, 0, 0, false)
+	view.TitlePrmt.AddItem(view.AssertionsPrmt, 0, 0, false)
+	view.TitlePrmt.AddItem(view.ExtractionsPrmt, 0, 0, false)
+
+	responsePrmt := tview.NewFlex().SetDirection(tview.FlexRow)
+	responsePrmt.AddItem(view.FilterPrmt, 1, 0, false)
+	responsePrmt.AddItem(view.ResponsePrmt, 0, 1, false)
 
 	flex := tview.NewFlex()
 	flex.AddItem(view.TitlePrmt, 0, 1, false)
 	flex.AddItem(tview.NewBox().SetBorder(false), 2, 0, false)
-	flex.AddItem(view.ResponsePrmt, 0, 2, false)
+	flex.AddItem(responsePrmt, 0, 2, false)
+	flex.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Name() {
+		case "Ctrl+Down":
+			if view.RequestPrmt.HasFocus() {
+				view.App.SetFocus(view.HeadersPrmt)
+			}
+		case "Ctrl+Up":
+			if view.HeadersPrmt.HasFocus() {
+				view.App.SetFocus(view.RequestPrmt)
+			}
+		}
+		return event
+	})
 
 	view.ParentPrmt = tview.NewFrame(flex).SetBorders(0, 0, 0, 0, 0, 0)
+
+	view.AppCtx.AddListenerMRD["requestResponseViewFilter"] = func(makeRequestData models.MakeRequestData) {
+		view.filter = makeRequestData.ResponseFilter
+		view.FilterPrmt.SetText(view.filter)
+	}
 }
 
 // Display displays request & response data
@@ -87,6 +195,10 @@ func (view *RequestResponseView) Display(client *httpclient.HTTPClient, data str
 
 	sb.WriteString("\r\n")
 	sb.WriteString(format(view.Labels["host"], client.Request.Host))
+	if client.Proxy != "" {
+		sb.WriteString("\r\n")
+		sb.WriteString(format(view.Labels["proxy"], tview.Escape(client.Proxy)))
+	}
 
 	// Content-Type
 	sb.WriteString("\r\n")
@@ -102,19 +214,29 @@ func (view *RequestResponseView) Display(client *httpclient.HTTPClient, data str
 	// Response header
 	sb.WriteString("\r\n\r\n")
 	sb.WriteString(format(view.Labels["http"]+"[white]/"+client.Response.HTTP, client.Response.Status))
-	sb.WriteString("\r\n")
-	sb.WriteString(format(view.Labels["contentLength"], client.Response.Contentlength))
-	sb.WriteString("\r\n")
-	sb.WriteString(format(view.Labels["contentType"], client.Response.ContentType))
-	sb.WriteString("\r\n")
-	sb.WriteString(format(view.Labels["date"], client.Response.Date))
-	sb.WriteString("\r\n")
-	sb.WriteString(format(view.Labels["referrerPolicy"], client.Response.Referrerpolicy))
-	sb.WriteString("\r\n")
-	sb.WriteString(format(view.Labels["connection"], client.Response.Connection))
+
+	// TLS connection & peer certificate chain
+	if client.TLS != nil {
+		protocol := client.TLS.Version + " " + client.TLS.CipherSuite
+		if client.TLS.Protocol != "" {
+			protocol += " (" + client.TLS.Protocol + ")"
+		}
+		sb.WriteString("\r\n\r\n")
+		sb.WriteString(format(view.Labels["tlsConnection"], protocol))
+		sb.WriteString("\r\n")
+		sb.WriteString("[" + utils.BlueColorName + "]" + view.Labels["certificates"])
+		for index, certificate := range client.TLS.Certificates {
+			sb.WriteString("\r\n")
+			sb.WriteString(format(strconv.Itoa(index), tview.Escape(certificate.Subject)))
+			sb.WriteString("\r\n  [gray]" + view.Labels["issuer"] + " " + tview.Escape(certificate.Issuer) +
+				", " + view.Labels["expires"] + " " + certificate.NotAfter.Format("2006-01-02"))
+		}
+	}
 
 	// Set request prmt text
 	view.RequestPrmt.SetText(sb.String()).SetTextAlign(tview.AlignLeft)
+	view.displayHeaders(client)
+	view.displayTiming(client.Timing)
 
 	// Log if error status
 	status := client.Response.StatusCode
@@ -122,8 +244,214 @@ func (view *RequestResponseView) Display(client *httpclient.HTTPClient, data str
 		view.Logger(view.Labels["status"]+": "+client.Response.Status, "error")
 	}
 
-	// Set the body response prmt text
-	view.setResponsePrmtText(utils.FormatLog(data, "data"))
+	// Set the body response prmt text (pretty-printed if it's possible)
+	view.body = data
+	view.contentType = client.Response.ContentType
+	view.LogBuffer = view.LogBuffer + utils.FormatLog("", "data")
+	view.bodyStart = len(view.LogBuffer)
+	view.setResponsePrmtText(view.formatBody())
+	view.bodyEnd = len(view.LogBuffer)
+}
+
+// ApplyFilter re-renders only the part of the last response body matching the jq/JSONPath @expression
+// (the whole body if the expression is empty)
+func (view *RequestResponseView) ApplyFilter(expression string) {
+	view.filter = strings.TrimSpace(expression)
+	view.refreshBody()
+}
+
+// formatBody formats the response body (filtered by the current expression)
+func (view *RequestResponseView) formatBody() string {
+	if view.filter == "" {
+		return utils.FormatBody(view.contentType, view.body, view.pretty)
+	}
+
+	value, error := core.JSONQuery([]byte(view.body), view.filter)
+	if error != nil {
+		return "[red]" + tview.Escape(view.filter+": "+error.Error())
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(value)
+
+	return utils.FormatBody("application/json", strings.TrimSpace(buffer.String()), view.pretty)
+}
+
+// GetBody returns the body of the last response
+func (view *RequestResponseView) GetBody() string {
+	return view.body
+}
+
+// TogglePretty switches the display of the last response body between raw and pretty-printed
+func (view *RequestResponseView) TogglePretty() {
+	view.pretty = !view.pretty
+	view.refreshBody()
+}
+
+// refreshBody re-renders the body of the last response in the log buffer
+func (view *RequestResponseView) refreshBody() {
+	if view.body == "" || view.bodyEnd > len(view.LogBuffer) {
+		return
+	}
+
+	body := view.formatBody() + "\r\n"
+	view.LogBuffer = view.LogBuffer[:view.bodyStart] + body + view.LogBuffer[view.bodyEnd:]
+	view.bodyEnd = view.bodyStart + len(body)
+
+	view.ResponsePrmt.SetText(view.LogBuffer)
+}
+
+// displayHeaders displays all the (multi-valued) response headers sorted by name
+func (view *RequestResponseView) displayHeaders(client *httpclient.HTTPClient) {
+	view.HeadersPrmt.Clear()
+
+	title := view.Labels["headers"] + " (" + strconv.Itoa(len(client.HeadersResponse)) + ")"
+	view.HeadersPrmt.SetCell(0, 0, tview.NewTableCell(title).SetTextColor(tcell.ColorYellow).SetAttributes(tcell.AttrBold).SetSelectable(false))
+	view.HeadersPrmt.SetCell(0, 1, tview.NewTableCell("").SetSelectable(false))
+
+	if len(client.HeadersResponse) == 0 {
+		view.HeadersPrmt.SetCell(1, 0, tview.NewTableCell(view.Labels["noHeader"]).SetSelectable(false))
+		return
+	}
+
+	row := 1
+	for _, key := range client.SortedHeadersResponse() {
+		for _, value := range client.HeadersResponse[key] {
+			view.HeadersPrmt.SetCell(row, 0, tview.NewTableCell(key).SetTextColor(tcell.GetColor(utils.BlueColorName)))
+			view.HeadersPrmt.SetCell(row, 1, tview.NewTableCell(tview.Escape(value)).SetExpansion(1))
+			row++
+		}
+	}
+	view.HeadersPrmt.Select(1, 0).ScrollToBeginning()
+}
+
+// displayTiming displays the waterfall of the request phases (DNS, connect, TLS, TTFB, transfer)
+func (view *RequestResponseView) displayTiming(timing httpclient.Timing) {
+	const width = 30
+
+	phases := []struct {
+		label    string
+		color    string
+		duration time.Duration
+	}{
+		{view.Labels["dns"], "aqua", timing.DNSLookup},
+		{view.Labels["connect"], "orange", timing.TCPConnection},
+		{view.Labels["tls"], "mediumpurple", timing.TLSHandshake},
+		{view.Labels["ttfb"], utils.GreenColorName, timing.TimeToFirstByte},
+		{view.Labels["transfer"], utils.BlueColorName, timing.ContentTransfer},
+	}
+
+	total := timing.Total
+	if total <= 0 {
+		total = 1
+	}
+	scale := func(d time.Duration) int {
+		return int(int64(d) * width / int64(total))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("[yellow::b]" + view.Labels["timing"] + "[::-]")
+	if timing.ConnectionReused {
+		sb.WriteString(" [gray]" + view.Labels["reused"])
+	}
+	sb.WriteString("\r\n")
+
+	var offset time.Duration
+	for _, phase := range phases {
+		start := scale(offset)
+		length := scale(phase.duration)
+		if phase.duration > 0 && length == 0 {
+			length = 1
+		}
+		if start+length > width {
+			start = width - length
+		}
+		offset += phase.duration
+
+		sb.WriteString(fmt.Sprintf("[white]%-19s", phase.label))
+		sb.WriteString("[gray]" + strings.Repeat("·", start))
+		sb.WriteString("[" + phase.color + "]" + strings.Repeat("█", length))
+		sb.WriteString("[gray]" + strings.Repeat("·", width-start-length))
+		sb.WriteString(fmt.Sprintf("[white] %8s\r\n", phase.duration.Round(100*time.Microsecond)))
+	}
+	sb.WriteString(fmt.Sprintf("[::b]%-19s%s %8s[::-]", view.Labels["total"], strings.Repeat(" ", width), timing.Total.Round(100*time.Microsecond)))
+
+	view.TimingPrmt.SetText(sb.String())
+	view.TitlePrmt.ResizeItem(view.TimingPrmt, len(phases)+3, 0)
+}
+
+// DisplayAssertions displays the pass/fail block of the evaluated assertions (hidden if no assertion)
+func (view *RequestResponseView) DisplayAssertions(results []models.AssertionResult) {
+	if len(results) == 0 {
+		view.AssertionsPrmt.SetText("")
+		view.TitlePrmt.ResizeItem(view.AssertionsPrmt, 0, 0)
+		return
+	}
+
+	failed := models.FailedAssertions(results)
+
+	var sb strings.Builder
+	if failed > 0 {
+		sb.WriteString("[red]")
+	} else {
+		sb.WriteString("[" + utils.GreenColorName + "]")
+	}
+	sb.WriteString("[::b]" + view.Labels["assertions"] + " " + strconv.Itoa(len(results)-failed) + "/" + strconv.Itoa(len(results)) + "[::-]\r\n")
+
+	for _, result := range results {
+		if result.Passed {
+			sb.WriteString("[" + utils.GreenColorName + "]" + view.Labels["passed"])
+		} else {
+			sb.WriteString("[red]" + view.Labels["failed"])
+		}
+		sb.WriteString("[white] " + tview.Escape(result.Assertion.String()))
+		if result.Message != "" {
+			sb.WriteString(" [gray](" + tview.Escape(result.Message) + ")")
+		}
+		sb.WriteString("\r\n")
+	}
+
+	view.AssertionsPrmt.SetText(sb.String()).ScrollToBeginning()
+	view.TitlePrmt.ResizeItem(view.AssertionsPrmt, len(results)+2, 0)
+}
+
+// DisplayExtractions displays the values extracted into the context variables (hidden if no extraction rule)
+func (view *RequestResponseView) DisplayExtractions(results []models.ExtractionResult) {
+	if len(results) == 0 {
+		view.ExtractionsPrmt.SetText("")
+		view.TitlePrmt.ResizeItem(view.ExtractionsPrmt, 0, 0)
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString("[yellow::b]" + view.Labels["extractions"] + "[::-]\r\n")
+	for _, result := range results {
+		sb.WriteString("[" + utils.BlueColorName + "]" + result.Extraction.Variable + "[white] ")
+		if result.Error != "" {
+			sb.WriteString("[red]" + tview.Escape(result.Error))
+		} else {
+			sb.WriteString(tview.Escape(result.Value))
+		}
+		sb.WriteString("\r\n")
+	}
+
+	view.ExtractionsPrmt.SetText(sb.String()).ScrollToBeginning()
+	view.TitlePrmt.ResizeItem(view.ExtractionsPrmt, len(results)+2, 0)
+}
+
+// DisplayProgress displays a spinner with the elapsed time of the running request
+func (view *RequestResponseView) DisplayProgress(elapsed time.Duration) {
+	view.progressFrame = (view.progressFrame + 1) % len(utils.SpinnerFrames)
+
+	progress := "[yellow]" + utils.SpinnerFrames[view.progressFrame] + " [white]" + view.Labels["progress"] + " " + elapsed.Round(100*time.Millisecond).String()
+	view.ResponsePrmt.SetText(view.LogBuffer + progress)
+}
+
+// DisplayCancelled displays the cancellation of the running request
+func (view *RequestResponseView) DisplayCancelled(elapsed time.Duration) {
+	view.Logger(view.Labels["cancelled"]+" "+elapsed.Round(time.Millisecond).String(), "warn")
 }
 
 // Logger logs to the response prmt
@@ -134,6 +462,7 @@ func (view *RequestResponseView) Logger(message string, mode string) {
 // ResetLogBuffer resets the log buffer
 func (view *RequestResponseView) ResetLogBuffer() {
 	view.LogBuffer = ""
+	view.body = ""
 }
 
 func (view *RequestResponseView) setResponsePrmtText(data string) {
@@ -0,0 +1,267 @@
+package views
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/joakim-ribier/gttp/core"
+	"github.com/joakim-ribier/gttp/models"
+	"github.com/joakim-ribier/gttp/utils"
+	"github.com/rivo/tview"
+)
+
+// RunnerView represents the collection runner which executes all the requests of a project
+type RunnerView struct {
+	App    *tview.Application
+	AppCtx *models.AppCtx
+
+	Labels map[string]string
+
+	FormPrmt    *tview.Form
+	TablePrmt   *tview.Table
+	SummaryPrmt *tview.TextView
+	ParentPrmt  tview.Primitive
+
+	// Actions
+	Run    func(projectName string, env string, stopOnFailure bool)
+	Stop   func()
+	Export func(filename string) error
+}
+
+// NewRunnerView returns the view for the collection runner
+func NewRunnerView(
+	app *tview.Application,
+	ctx *models.AppCtx,
+	run func(projectName string, env string, stopOnFailure bool),
+	stop func(),
+	export func(filename string) error) *RunnerView {
+
+	labels := make(map[string]string)
+	labels["title"] = "Collection Runner"
+	labels["project"] = "Project"
+	labels["context"] = "Execution Context"
+	labels["stop_on_failure"] = "Stop on failure"
+	labels["junit_file"] = "JUnit XML file"
+	labels["run"] = "Run"
+	labels["stop"] = "Stop"
+	labels["export"] = "Export"
+	labels["empty"] = "Select a project and press 'Run' (or press Enter on a project of the tree)..."
+	labels["no_request"] = "No request in this project..."
+	labels["running"] = "running..."
+	labels["pending"] = "pending"
+	labels["skipped"] = "skipped"
+	labels["passed"] = "PASS"
+	labels["failed"] = "FAIL"
+	labels["exported"] = "exported"
+
+	return &RunnerView{
+		App:    app,
+		AppCtx: ctx,
+		Labels: labels,
+		Run:    run,
+		Stop:   stop,
+		Export: export,
+	}
+}
+
+// InitView builds all components to display correctly the view
+func (view *RunnerView) InitView() {
+	view.FormPrmt = tview.NewForm()
+	view.FormPrmt.SetBorder(false)
+	view.FormPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	// New field - "Project"
+	view.FormPrmt.AddDropDown(view.Labels["project"], nil, 0, nil)
+
+	// New field - "Execution Context"
+	view.FormPrmt.AddDropDown(view.Labels["context"], nil, 0, nil)
+
+	// New field - "Stop on failure"
+	view.FormPrmt.AddCheckbox(view.Labels["stop_on_failure"], true, nil)
+
+	// New field - "JUnit XML file"
+	view.FormPrmt.AddInputField(view.Labels["junit_file"], "", 0, nil, nil)
+
+	// Add generic events to inputField
+	utils.AddInputFieldEventForm(view.FormPrmt, view.Labels["junit_file"])
+
+	// New field - "Run"
+	view.FormPrmt.AddButton(view.Labels["run"], func() {
+		view.RunProject()
+	})
+
+	// New field - "Stop"
+	view.FormPrmt.AddButton(view.Labels["stop"], func() {
+		view.Stop()
+	})
+
+	// New field - "Export"
+	view.FormPrmt.AddButton(view.Labels["export"], func() {
+		filename := utils.GetInputFieldForm(view.FormPrmt, view.Labels["junit_file"]).GetText()
+		if error := view.Export(filename); error != nil {
+			view.SummaryPrmt.SetText("[red]" + tview.Escape(error.Error()))
+			return
+		}
+		view.SummaryPrmt.SetText("[" + utils.GreenColorName + "]'" + tview.Escape(filename) + "' " + view.Labels["exported"])
+	})
+
+	view.TablePrmt = tview.NewTable().SetBorders(false).SetSelectable(true, false)
+	view.TablePrmt.SetBackgroundColor(utils.BackGrayColor)
+	view.TablePrmt.SetCell(0, 0, tview.NewTableCell(view.Labels["empty"]).SetSelectable(false))
+
+	view.SummaryPrmt = tview.NewTextView().SetDynamicColors(true)
+	view.SummaryPrmt.SetBackgroundColor(utils.BackGrayColor)
+	view.SummaryPrmt.SetBorderPadding(0, 0, 1, 1)
+
+	titlePrmt := utils.MakeTitlePrmt(view.Labels["title"])
+	titlePrmt.AddItem(view.FormPrmt, 0, 1, false)
+
+	resultsPrmt := tview.NewFlex().SetDirection(tview.FlexRow)
+	resultsPrmt.SetBackgroundColor(utils.BackGrayColor)
+	resultsPrmt.SetBorderPadding(1, 0, 0, 0)
+	resultsPrmt.AddItem(view.TablePrmt, 0, 1, false)
+	resultsPrmt.AddItem(view.SummaryPrmt, 2, 0, false)
+
+	flex := tview.NewFlex()
+	flex.AddItem(titlePrmt, 0, 1, false)
+	flex.AddItem(tview.NewBox().SetBorder(false), 2, 0, false)
+	flex.AddItem(resultsPrmt, 0, 2, false)
+	flex.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Name() {
+		case "Ctrl+Down":
+			view.App.SetFocus(view.TablePrmt)
+		case "Ctrl+Up":
+			view.App.SetFocus(view.FormPrmt)
+		}
+		return event
+	})
+
+	view.ParentPrmt = tview.NewFrame(flex).SetBorders(0, 0, 0, 0, 0, 0)
+
+	// Add listener to refresh the projects when the requests are changing...
+	view.AppCtx.AddListenerMRD["runnerView"] = func(data models.MakeRequestData) {
+		view.AppCtx.PrintTrace("RunnerView.InitView{...}.AddListenerMRD")
+
+		prmt := utils.GetDropDownFieldForm(view.FormPrmt, view.Labels["project"])
+		_, current := prmt.GetCurrentOption()
+
+		projects, _ := view.AppCtx.GetOutput().SortDataAPIsByProjectName()
+		prmt.SetOptions(projects, nil)
+		if index := core.StringSlice(projects).GetIndex(current); index != -1 {
+			prmt.SetCurrentOption(index)
+		} else {
+			prmt.SetCurrentOption(0)
+		}
+	}
+
+	// Add listener to refresh the contexts when the context is changing...
+	view.AppCtx.AddContextListener["runnerView"] = func(context models.Context) {
+		view.AppCtx.PrintTrace("RunnerView.InitView{...}.AddContextListener")
+
+		envs := context.GetEnvsName()
+
+		prmt := utils.GetDropDownFieldForm(view.FormPrmt, view.Labels["context"])
+		_, current := prmt.GetCurrentOption()
+		prmt.SetOptions(envs, nil)
+
+		index := envs.GetIndex(current)
+		if index == -1 {
+			index = envs.GetIndex("default")
+		}
+		prmt.SetCurrentOption(index)
+	}
+}
+
+// SetProject selects the @projectName in the project dropdown prmt (if exists)
+func (view *RunnerView) SetProject(projectName string) {
+	projects, _ := view.AppCtx.GetOutput().SortDataAPIsByProjectName()
+	if index := core.StringSlice(projects).GetIndex(projectName); index != -1 {
+		utils.GetDropDownFieldForm(view.FormPrmt, view.Labels["project"]).SetCurrentOption(index)
+	}
+}
+
+// RunProject runs the selected project with the form options
+func (view *RunnerView) RunProject() {
+	_, projectName := utils.GetDropDownFieldForm(view.FormPrmt, view.Labels["project"]).GetCurrentOption()
+	_, env := utils.GetDropDownFieldForm(view.FormPrmt, view.Labels["context"]).GetCurrentOption()
+	stopOnFailure := view.FormPrmt.GetFormItemByLabel(view.Labels["stop_on_failure"]).(*tview.Checkbox).IsChecked()
+
+	view.Run(projectName, env, stopOnFailure)
+}
+
+// Start displays all the requests of the @runner which are going to be executed
+func (view *RunnerView) Start(runner models.Runner) {
+	view.TablePrmt.Clear()
+	view.SummaryPrmt.SetText("")
+
+	if len(runner.Results) == 0 {
+		view.TablePrmt.SetCell(0, 0, tview.NewTableCell(view.Labels["no_request"]).SetSelectable(false))
+		return
+	}
+
+	for row, result := range runner.Results {
+		view.displayRow(row, result, view.Labels["pending"], tcell.ColorGray)
+	}
+	view.displayRow(0, runner.Results[0], view.Labels["running"], tcell.ColorYellow)
+	view.TablePrmt.Select(0, 0).ScrollToBeginning()
+}
+
+// DisplayResult displays the @result of the request at @index and the next one as running
+func (view *RunnerView) DisplayResult(index int, result models.RunnerResult) {
+	if result.Passed() {
+		view.displayRow(index, result, view.Labels["passed"], tcell.GetColor(utils.GreenColorName))
+	} else {
+		view.displayRow(index, result, view.Labels["failed"], tcell.ColorRed)
+	}
+	view.TablePrmt.Select(index, 0)
+
+	if index+1 < view.TablePrmt.GetRowCount() {
+		view.TablePrmt.GetCell(index+1, 0).SetText(view.Labels["running"]).SetTextColor(tcell.ColorYellow)
+	}
+}
+
+// Finish displays the summary of the @runner
+func (view *RunnerView) Finish(runner models.Runner) {
+	for row, result := range runner.Results {
+		if result.Skipped {
+			view.displayRow(row, result, view.Labels["skipped"], tcell.ColorGray)
+		}
+	}
+
+	passed, failed, skipped := runner.Count()
+
+	color := utils.GreenColorName
+	if failed > 0 {
+		color = "red"
+	}
+	view.SummaryPrmt.SetText("[" + color + "]" +
+		strconv.Itoa(passed) + " passed, " +
+		strconv.Itoa(failed) + " failed, " +
+		strconv.Itoa(skipped) + " skipped[white] in " +
+		runner.Duration().Round(time.Millisecond).String())
+}
+
+func (view *RunnerView) displayRow(row int, result models.RunnerResult, state string, color tcell.Color) {
+	alias := result.Request.Alias
+	if alias == "" {
+		alias = result.Request.URL.String()
+	}
+
+	status, duration, failure := "", "", ""
+	if !result.Skipped {
+		status = "---"
+		if result.StatusCode != 0 {
+			status = strconv.Itoa(result.StatusCode)
+		}
+		duration = result.Duration.Round(time.Millisecond).String()
+		failure = result.Failure()
+	}
+
+	view.TablePrmt.SetCell(row, 0, tview.NewTableCell(state).SetTextColor(color))
+	view.TablePrmt.SetCell(row, 1, tview.NewTableCell(result.Request.Method.Label()).SetTextColor(tcell.GetColor(utils.BlueColorName)))
+	view.TablePrmt.SetCell(row, 2, tview.NewTableCell(tview.Escape(alias)).SetExpansion(1))
+	view.TablePrmt.SetCell(row, 3, tview.NewTableCell(status).SetTextColor(color))
+	view.TablePrmt.SetCell(row, 4, tview.NewTableCell(duration).SetAlign(tview.AlignRight))
+	view.TablePrmt.SetCell(row, 5, tview.NewTableCell(tview.Escape(failure)).SetTextColor(tcell.ColorRed))
+}
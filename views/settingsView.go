@@ -5,6 +5,7 @@ import (
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/joakim-ribier/gttp/components"
+	"github.com/joakim-ribier/gttp/core"
 	"github.com/joakim-ribier/gttp/models"
 	"github.com/joakim-ribier/gttp/utils"
 	"github.com/rivo/tview"
@@ -19,10 +20,25 @@ type SettingsView struct {
 
 	TitlePrmt  tview.Primitive
 	ParentPrmt tview.Primitive
+
+	// Actions
+	ImportData    func(format string, filename string) ([]string, error)
+	ExportData    func(format string, filename string, projectName string) ([]string, error)
+	UnlockSecrets func(passphrase string) error
+	SetSecret     func(env string, variable string, value string) error
+	RemoveSecret  func(env string, variable string) error
 }
 
 // NewSettingsView returns the settings view of the app
-func NewSettingsView(app *tview.Application, ev *models.AppCtx) *SettingsView {
+func NewSettingsView(
+	app *tview.Application,
+	ev *models.AppCtx,
+	importData func(format string, filename string) ([]string, error),
+	exportData func(format string, filename string, projectName string) ([]string, error),
+	unlockSecrets func(passphrase string) error,
+	setSecret func(env string, variable string, value string) error,
+	removeSecret func(env string, variable string) error) *SettingsView {
+
 	var legendSB strings.Builder
 	legendSB.WriteString("[" + utils.GreenColorName + "]Update the display format of the API(s) tree.\r\n\r\n")
 	legendSB.WriteString("Change the patterns order to update the view:\r\n\r\n")
@@ -44,8 +60,13 @@ func NewSettingsView(app *tview.Application, ev *models.AppCtx) *SettingsView {
 
 	var executePageSB strings.Builder
 	executePageSB.WriteString("[" + utils.GreenColorName + "]Execute http request\r\n\r\n")
-	executePageSB.WriteString("Choose a request (" + string(9658) + " " + utils.SelectAPIShortcut + ") and press (" + utils.ExecuteShortcut + ") to execute it.\r\n\n")
-	executePageSB.WriteString("* Select the execution context, depend on environment settings (" + utils.SettingsShortcut + ").")
+	executePageSB.WriteString("Choose a request (" + string(rune(9658)) + " " + utils.SelectAPIShortcut + ") and press (" + utils.ExecuteShortcut + ") to execute it.\r\n\n")
+	executePageSB.WriteString("* Select the execution context, depend on environment settings (" + utils.SettingsShortcut + ").\r\n")
+	executePageSB.WriteString("* The request runs in background, press (" + utils.ShortcutX + ") to abort it.\r\n")
+	executePageSB.WriteString("* JSON, XML and HTML responses are pretty-printed, press (" + utils.ShortcutP + ") on the response view to switch to the raw body.\r\n")
+	executePageSB.WriteString("* Press (" + utils.ShortcutFilter + ") on the response view to filter the json body with a jq-like (.items[].id) or JSONPath ($.items[*].id) expression, it's remembered per saved request.\r\n")
+	executePageSB.WriteString("* Press (" + utils.ShortcutY + ") to explore a json response as a tree (copy a value, its JSONPath or save it as context variable).\r\n")
+	executePageSB.WriteString("* Press Enter on a project of the tree or (" + utils.ShortcutL + ") to run all the requests of a project.")
 
 	labels := make(map[string]string)
 	labels["title"] = "Application Settings"
@@ -54,6 +75,20 @@ func NewSettingsView(app *tview.Application, ev *models.AppCtx) *SettingsView {
 	labels["menu_tree_format_desc"] = "Update the display format of the API(s) tree"
 	labels["menu_tree_overview_title"] = "Example of tree formatting"
 
+	labels["menu_timeout_title"] = "Request timeouts"
+	labels["menu_timeout_desc"] = "Default connect & total timeouts"
+
+	labels["menu_tls_title"] = "TLS / Certificates"
+	labels["menu_tls_desc"] = "CA, client certificate & verification"
+
+	labels["menu_proxy_title"] = "Proxy"
+	labels["menu_proxy_desc"] = "HTTP, HTTPS or SOCKS5 proxy"
+	labels["menu_oauth2_title"] = "OAuth2"
+	labels["menu_oauth2_desc"] = "token of an execution context"
+
+	labels["menu_import_title"] = "Import / Export"
+	labels["menu_import_desc"] = ".http, Postman, OpenAPI / Swagger..."
+
 	labels["menu_env_title"] = "Environment"
 	labels["menu_env_desc"] = "Add variables for specific env"
 
@@ -76,11 +111,72 @@ func NewSettingsView(app *tview.Application, ev *models.AppCtx) *SettingsView {
 	labels["variables"] = "Variables"
 	labels["value"] = "Value"
 	labels["variable"] = "Variable"
+	labels["format"] = "Format"
+	labels["file"] = "File"
+	labels["import"] = "Import"
+	labels["export"] = "Export"
+	labels["exported"] = "exported"
+	labels["project"] = "Project (export)"
+	labels["imported"] = "imported"
+	labels["not_imported"] = "Not imported"
+	labels["not_exported"] = "Not exported"
+	labels["import_description"] = "[" + utils.GreenColorName + "]Import requests & variables from another tool.\r\n\r\n" +
+		"* The requests are merged in the current data file (same method & url => replaced)\r\n" +
+		"* The \"{{var}}\" placeholders are translated to \"{var}\"\r\n" +
+		"* The OpenAPI servers are imported as execution contexts (\"{baseurl}\")\r\n" +
+		"* The unsupported items (scripts, auth helpers...) are listed below\r\n" +
+		"* The .http export writes also the environments to \"http-client.env.json\""
+	labels["secret"] = "Secret"
+	labels["passphrase"] = "Passphrase"
+	labels["unlock"] = "Unlock"
+	labels["unlocked"] = "Secrets unlocked"
+	labels["scope"] = "Scope"
+	labels["global"] = "(global)"
+	labels["ca_file"] = "CA file"
+	labels["cert_file"] = "Client cert."
+	labels["key_file"] = "Client key"
+	labels["min_version"] = "Min. TLS version"
+	labels["insecure"] = "Skip verification"
+	labels["saved"] = "saved"
+	labels["tls_description"] = "[" + utils.GreenColorName + "]TLS settings of the requests (PEM files).\r\n\r\n" +
+		"* \"(global)\" => default settings used by all execution contexts\r\n" +
+		"* An execution context overrides the global values which are defined (the CA, the client cert. & key, the min. version)\r\n" +
+		"* The verification is skipped if it's skipped globally or by the execution context"
+	labels["proxy_url"] = "Proxy URL"
+	labels["proxy_username"] = "Username"
+	labels["proxy_password"] = "Password"
+	labels["no_proxy"] = "No proxy"
+	labels["proxy_description"] = "[" + utils.GreenColorName + "]Proxy of the requests (ex. http://proxy:3128, socks5://proxy:1080).\r\n\r\n" +
+		"* \"(global)\" => default proxy used by all execution contexts (empty => HTTP_PROXY/HTTPS_PROXY/NO_PROXY env. variables)\r\n" +
+		"* An execution context overrides the global proxy if its URL is defined (\"" + models.ProxyDirect + "\" => no proxy)\r\n" +
+		"* No proxy => hosts which are not proxied (ex. localhost, .corp.net, 10.0.0.0/8)"
+	labels["grant_type"] = "Grant type"
+	labels["token_url"] = "Token URL"
+	labels["client_id"] = "Client id"
+	labels["client_secret"] = "Client secret"
+	labels["scopes"] = "Scopes"
+	labels["oauth2_username"] = "Username"
+	labels["oauth2_password"] = "Password"
+	labels["oauth2_description"] = "[" + utils.GreenColorName + "]OAuth2 profile of an execution context (empty token URL => no profile).\r\n\r\n" +
+		"* The token is fetched before the requests, cached until its expiry & refreshed automatically\r\n" +
+		"* It's sent as a Bearer token unless the request defines its own authentication (" + utils.ShortcutH + ")\r\n" +
+		"* The values can use the variables of the execution context (ex. Client secret: {client_secret})"
+	labels["connect_timeout"] = "Connect timeout"
+	labels["total_timeout"] = "Total timeout"
+	labels["timeout_description"] = "[" + utils.GreenColorName + "]Default timeouts used by all requests (ex. 500ms, 5s, 1m).\r\n\r\n" +
+		"Each request can override them (" + utils.ShortcutH + ").\r\n\r\n" +
+		"* Connect => TCP connection & TLS handshake (default " + models.DefaultConnectTimeout + ")\r\n" +
+		"* Total   => whole request, response body included (default " + models.DefaultTotalTimeout + ")"
 
 	return &SettingsView{
-		App:    app,
-		AppCtx: ev,
-		Labels: labels,
+		App:           app,
+		AppCtx:        ev,
+		Labels:        labels,
+		ImportData:    importData,
+		ExportData:    exportData,
+		UnlockSecrets: unlockSecrets,
+		SetSecret:     setSecret,
+		RemoveSecret:  removeSecret,
 	}
 }
 
@@ -93,6 +189,11 @@ func (view *SettingsView) InitView() {
 	pages.SetBackgroundColor(utils.BackGrayColor)
 	pages.AddPage("EnvPage", view.makeEnvPage(mapMenuToFocusPrmt), true, false)
 	pages.AddPage("APITreeFormatPage", view.makeAPITreeFormatPage(mapMenuToFocusPrmt), true, false)
+	pages.AddPage("TimeoutPage", view.makeTimeoutPage(mapMenuToFocusPrmt), true, false)
+	pages.AddPage("TLSPage", view.makeTLSPage(mapMenuToFocusPrmt), true, false)
+	pages.AddPage("ProxyPage", view.makeProxyPage(mapMenuToFocusPrmt), true, false)
+	pages.AddPage("OAuth2Page", view.makeOAuth2Page(mapMenuToFocusPrmt), true, false)
+	pages.AddPage("ImportPage", view.makeImportPage(mapMenuToFocusPrmt), true, false)
 	pages.AddPage("ManPage", view.makeManPage(mapMenuToFocusPrmt), true, false)
 
 	// Menu
@@ -130,6 +231,26 @@ func (view *SettingsView) makeMenu(pages *tview.Pages, mapMenuToFocusPrmt map[st
 			pages.SwitchToPage("APITreeFormatPage")
 			view.App.SetFocus(mapMenuToFocusPrmt["menu_tree_format"])
 		}).
+		AddItem(view.Labels["menu_import_title"], view.Labels["menu_import_desc"], 'i', func() {
+			pages.SwitchToPage("ImportPage")
+			view.App.SetFocus(mapMenuToFocusPrmt["menu_import"])
+		}).
+		AddItem(view.Labels["menu_timeout_title"], view.Labels["menu_timeout_desc"], 'r', func() {
+			pages.SwitchToPage("TimeoutPage")
+			view.App.SetFocus(mapMenuToFocusPrmt["menu_timeout"])
+		}).
+		AddItem(view.Labels["menu_tls_title"], view.Labels["menu_tls_desc"], 's', func() {
+			pages.SwitchToPage("TLSPage")
+			view.App.SetFocus(mapMenuToFocusPrmt["menu_tls"])
+		}).
+		AddItem(view.Labels["menu_proxy_title"], view.Labels["menu_proxy_desc"], 'p', func() {
+			pages.SwitchToPage("ProxyPage")
+			view.App.SetFocus(mapMenuToFocusPrmt["menu_proxy"])
+		}).
+		AddItem(view.Labels["menu_oauth2_title"], view.Labels["menu_oauth2_desc"], 'o', func() {
+			pages.SwitchToPage("OAuth2Page")
+			view.App.SetFocus(mapMenuToFocusPrmt["menu_oauth2"])
+		}).
 		AddItem(view.Labels["menu_man_title"], view.Labels["menu_man_desc"], 'z', func() {
 			pages.SwitchToPage("ManPage")
 			view.App.SetFocus(mapMenuToFocusPrmt["menu_man"])
@@ -205,7 +326,7 @@ func (view *SettingsView) makeAPITreeFormatPage(mapMenuToFocusPrmt map[string]tv
 	// Add tree APIs component
 
 	treeAPICpnt := components.NewTreeCpnt(view.App, view.AppCtx)
-	tree := treeAPICpnt.Make(nil, nil)
+	tree := treeAPICpnt.Make(nil, nil, nil)
 	tree.SetBackgroundColor(utils.BackGrayColor)
 	treeAPICpnt.UpdateTitle(view.Labels["menu_tree_overview_title"])
 
@@ -263,9 +384,500 @@ func (view *SettingsView) makeAPITreeFormatPage(mapMenuToFocusPrmt map[string]tv
 	return flex
 }
 
+func (view *SettingsView) makeImportPage(mapMenuToFocusPrmt map[string]tview.Primitive) *tview.Flex {
+	// Description prmt
+	descPrmt := tview.NewTextView().SetDynamicColors(true)
+	descPrmt.SetText(view.Labels["import_description"])
+	descPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	// Result prmt
+	resultPrmt := tview.NewTextView().SetDynamicColors(true).SetScrollable(true)
+	resultPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	// Form
+	formPrmt := tview.NewForm()
+	formPrmt.SetBorder(false)
+	formPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	mapMenuToFocusPrmt["menu_import"] = formPrmt
+
+	// New field - "Format"
+	formPrmt.AddDropDown(view.Labels["format"], utils.ImportFormatValues, 0, nil)
+
+	// New field - "File"
+	formPrmt.AddInputField(view.Labels["file"], "", 0, nil, nil)
+
+	// Add generic events to inputField
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["file"])
+
+	// New field - "Project"
+	formPrmt.AddDropDown(view.Labels["project"], nil, 0, nil)
+
+	// New field - "Import"
+	formPrmt.AddButton(view.Labels["import"], func() {
+		_, format := utils.GetDropDownFieldForm(formPrmt, view.Labels["format"]).GetCurrentOption()
+		filename := utils.GetInputFieldForm(formPrmt, view.Labels["file"]).GetText()
+
+		warnings, error := view.ImportData(format, filename)
+		if error != nil {
+			resultPrmt.SetText("[red]" + tview.Escape(error.Error()))
+			return
+		}
+
+		var sb strings.Builder
+		sb.WriteString("[" + utils.GreenColorName + "]'" + tview.Escape(filename) + "' " + view.Labels["imported"] + "\r\n\r\n")
+		if len(warnings) > 0 {
+			sb.WriteString("[yellow]" + view.Labels["not_imported"] + ":[white]\r\n")
+			for _, warning := range warnings {
+				sb.WriteString("* " + tview.Escape(warning) + "\r\n")
+			}
+		}
+		resultPrmt.SetText(sb.String()).ScrollToBeginning()
+	})
+
+	// New field - "Export"
+	formPrmt.AddButton(view.Labels["export"], func() {
+		_, format := utils.GetDropDownFieldForm(formPrmt, view.Labels["format"]).GetCurrentOption()
+		_, projectName := utils.GetDropDownFieldForm(formPrmt, view.Labels["project"]).GetCurrentOption()
+		filename := utils.GetInputFieldForm(formPrmt, view.Labels["file"]).GetText()
+		if projectName == utils.AllProjectsValue {
+			projectName = ""
+		}
+
+		warnings, error := view.ExportData(format, filename, projectName)
+		if error != nil {
+			resultPrmt.SetText("[red]" + tview.Escape(error.Error()))
+			return
+		}
+
+		var sb strings.Builder
+		sb.WriteString("[" + utils.GreenColorName + "]'" + tview.Escape(filename) + "' " + view.Labels["exported"] + "\r\n\r\n")
+		if len(warnings) > 0 {
+			sb.WriteString("[yellow]" + view.Labels["not_exported"] + ":[white]\r\n")
+			for _, warning := range warnings {
+				sb.WriteString("* " + tview.Escape(warning) + "\r\n")
+			}
+		}
+		resultPrmt.SetText(sb.String()).ScrollToBeginning()
+	})
+
+	// Add listener to refresh the projects when the requests are changing...
+	view.AppCtx.AddListenerMRD["makeImportPage"] = func(data models.MakeRequestData) {
+		projects, _ := view.AppCtx.GetOutput().SortDataAPIsByProjectName()
+
+		prmt := utils.GetDropDownFieldForm(formPrmt, view.Labels["project"])
+		prmt.SetOptions(append([]string{utils.AllProjectsValue}, projects...), nil)
+		prmt.SetCurrentOption(0)
+	}
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow)
+	flex.SetBorderPadding(1, 1, 1, 1)
+	flex.AddItem(descPrmt, 8, 0, false)
+	flex.AddItem(formPrmt, 9, 0, false)
+	flex.AddItem(resultPrmt, 0, 1, false)
+
+	return flex
+}
+
+func (view *SettingsView) makeTimeoutPage(mapMenuToFocusPrmt map[string]tview.Primitive) *tview.Flex {
+	// Description prmt
+	descPrmt := tview.NewTextView().SetDynamicColors(true)
+	descPrmt.SetText(view.Labels["timeout_description"])
+	descPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	textViewError := tview.NewTextView()
+	textViewError.SetTextColor(tcell.ColorRed)
+	textViewError.SetBackgroundColor(utils.BackGrayColor)
+
+	// Form
+	formPrmt := tview.NewForm()
+	formPrmt.SetBorder(false)
+	formPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	mapMenuToFocusPrmt["menu_timeout"] = formPrmt
+
+	// New field - "Connect timeout"
+	formPrmt.AddInputField(view.Labels["connect_timeout"], "", 0, nil, nil)
+	// New field - "Total timeout"
+	formPrmt.AddInputField(view.Labels["total_timeout"], "", 0, nil, nil)
+
+	// Add generic events to inputField
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["connect_timeout"])
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["total_timeout"])
+
+	// New field - "Save"
+	formPrmt.AddButton(view.Labels["save"], func() {
+		timeout := models.Timeout{
+			Connect: utils.GetInputFieldForm(formPrmt, view.Labels["connect_timeout"]).GetText(),
+			Total:   utils.GetInputFieldForm(formPrmt, view.Labels["total_timeout"]).GetText(),
+		}
+		if error := timeout.Validate(); error != nil {
+			textViewError.SetText(error.Error())
+			return
+		}
+		textViewError.SetText("")
+
+		config := view.AppCtx.GetConfig()
+		config.Timeout = timeout
+
+		view.AppCtx.UpdateConfig(config)
+	})
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow)
+	flex.SetBorderPadding(1, 1, 1, 1)
+	flex.AddItem(descPrmt, 7, 0, false)
+	flex.AddItem(formPrmt, 0, 1, false)
+	flex.AddItem(textViewError, 1, 0, false)
+
+	view.AppCtx.AddListenerConfig["makeTimeoutPage"] = func(data models.Config) {
+		view.AppCtx.PrintTrace("SettingsView.makeTimeoutPage{...}.listener")
+
+		utils.GetInputFieldForm(formPrmt, view.Labels["connect_timeout"]).SetText(data.Timeout.Connect)
+		utils.GetInputFieldForm(formPrmt, view.Labels["total_timeout"]).SetText(data.Timeout.Total)
+	}
+
+	return flex
+}
+
+func (view *SettingsView) makeTLSPage(mapMenuToFocusPrmt map[string]tview.Primitive) *tview.Flex {
+	// Description prmt
+	descPrmt := tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+	descPrmt.SetText(view.Labels["tls_description"])
+	descPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	resultPrmt := tview.NewTextView().SetDynamicColors(true)
+	resultPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	// Form
+	formPrmt := tview.NewForm()
+	formPrmt.SetBorder(false)
+	formPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	mapMenuToFocusPrmt["menu_tls"] = formPrmt
+
+	// getTLS returns the TLS settings of the selected scope (global or execution context)
+	getTLS := func(scope string) models.TLS {
+		if scope == view.Labels["global"] {
+			return view.AppCtx.GetConfig().TLS
+		}
+		return view.AppCtx.GetOutput().Context.GetTLS(scope)
+	}
+
+	refreshForm := func(scope string) {
+		tls := getTLS(scope)
+		utils.GetInputFieldForm(formPrmt, view.Labels["ca_file"]).SetText(tls.CAFile)
+		utils.GetInputFieldForm(formPrmt, view.Labels["cert_file"]).SetText(tls.CertFile)
+		utils.GetInputFieldForm(formPrmt, view.Labels["key_file"]).SetText(tls.KeyFile)
+		utils.GetDropDownFieldForm(formPrmt, view.Labels["min_version"]).SetCurrentOption(models.TLSVersions.GetIndex(tls.MinVersion))
+		formPrmt.GetFormItemByLabel(view.Labels["insecure"]).(*tview.Checkbox).SetChecked(tls.InsecureSkipVerify)
+	}
+
+	// New field - "Scope"
+	formPrmt.AddDropDown(view.Labels["scope"], []string{view.Labels["global"]}, 0, nil)
+	// New fields - "CA file", "Client cert." & "Client key"
+	formPrmt.AddInputField(view.Labels["ca_file"], "", 0, nil, nil)
+	formPrmt.AddInputField(view.Labels["cert_file"], "", 0, nil, nil)
+	formPrmt.AddInputField(view.Labels["key_file"], "", 0, nil, nil)
+	// New field - "Min. TLS version"
+	formPrmt.AddDropDown(view.Labels["min_version"], models.TLSVersions, 0, nil)
+	// New field - "Skip verification"
+	formPrmt.AddCheckbox(view.Labels["insecure"], false, nil)
+
+	// Add generic events to inputField
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["ca_file"])
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["cert_file"])
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["key_file"])
+
+	selectScope := func(text string, index int) {
+		if index != -1 {
+			resultPrmt.SetText("")
+			refreshForm(text)
+		}
+	}
+	utils.GetDropDownFieldForm(formPrmt, view.Labels["scope"]).SetSelectedFunc(selectScope)
+
+	// New field - "Save"
+	formPrmt.AddButton(view.Labels["save"], func() {
+		_, scope := utils.GetDropDownFieldForm(formPrmt, view.Labels["scope"]).GetCurrentOption()
+		_, minVersion := utils.GetDropDownFieldForm(formPrmt, view.Labels["min_version"]).GetCurrentOption()
+
+		tls := models.TLS{
+			CAFile:             utils.GetInputFieldForm(formPrmt, view.Labels["ca_file"]).GetText(),
+			CertFile:           utils.GetInputFieldForm(formPrmt, view.Labels["cert_file"]).GetText(),
+			KeyFile:            utils.GetInputFieldForm(formPrmt, view.Labels["key_file"]).GetText(),
+			MinVersion:         minVersion,
+			InsecureSkipVerify: formPrmt.GetFormItemByLabel(view.Labels["insecure"]).(*tview.Checkbox).IsChecked(),
+		}
+		if error := tls.Validate(); error != nil {
+			resultPrmt.SetText("[red]" + tview.Escape(error.Error()))
+			return
+		}
+
+		if scope == view.Labels["global"] {
+			config := view.AppCtx.GetConfig()
+			config.TLS = tls
+			view.AppCtx.UpdateConfig(config)
+		} else {
+			context := view.AppCtx.GetOutput().Context.Copy()
+			context.SetTLS(scope, tls)
+			view.AppCtx.UpdateContext(context)
+		}
+		resultPrmt.SetText("[" + utils.GreenColorName + "]" + tview.Escape(scope) + " " + view.Labels["saved"])
+	})
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow)
+	flex.SetBorderPadding(1, 1, 1, 1)
+	flex.AddItem(descPrmt, 6, 0, false)
+	flex.AddItem(formPrmt, 0, 1, false)
+	flex.AddItem(resultPrmt, 1, 0, false)
+
+	// Refresh the scopes (execution contexts) & the selected settings
+	refresh := func() {
+		prmt := utils.GetDropDownFieldForm(formPrmt, view.Labels["scope"])
+		_, current := prmt.GetCurrentOption()
+
+		scopes := append([]string{view.Labels["global"]}, view.AppCtx.GetOutput().Context.GetEnvsName()...)
+		index := core.StringSlice(scopes).GetIndex(current)
+		if index == -1 {
+			index = 0
+		}
+		prmt.SetOptions(scopes, selectScope)
+		prmt.SetCurrentOption(index)
+	}
+
+	view.AppCtx.AddListenerConfig["makeTLSPage"] = func(data models.Config) {
+		view.AppCtx.PrintTrace("SettingsView.makeTLSPage{...}.listener")
+		refresh()
+	}
+	view.AppCtx.AddContextListener["makeTLSPage"] = func(data models.Context) {
+		refresh()
+	}
+
+	return flex
+}
+
+func (view *SettingsView) makeProxyPage(mapMenuToFocusPrmt map[string]tview.Primitive) *tview.Flex {
+	// Description prmt
+	descPrmt := tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+	descPrmt.SetText(view.Labels["proxy_description"])
+	descPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	resultPrmt := tview.NewTextView().SetDynamicColors(true)
+	resultPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	// Form
+	formPrmt := tview.NewForm()
+	formPrmt.SetBorder(false)
+	formPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	mapMenuToFocusPrmt["menu_proxy"] = formPrmt
+
+	// getProxy returns the proxy settings of the selected scope (global or execution context)
+	getProxy := func(scope string) models.Proxy {
+		if scope == view.Labels["global"] {
+			return view.AppCtx.GetConfig().Proxy
+		}
+		return view.AppCtx.GetOutput().Context.GetProxy(scope)
+	}
+
+	refreshForm := func(scope string) {
+		proxy := getProxy(scope)
+		utils.GetInputFieldForm(formPrmt, view.Labels["proxy_url"]).SetText(proxy.URL)
+		utils.GetInputFieldForm(formPrmt, view.Labels["proxy_username"]).SetText(proxy.Username)
+		utils.GetInputFieldForm(formPrmt, view.Labels["proxy_password"]).SetText(proxy.Password)
+		utils.GetInputFieldForm(formPrmt, view.Labels["no_proxy"]).SetText(proxy.NoProxy)
+	}
+
+	// New field - "Scope"
+	formPrmt.AddDropDown(view.Labels["scope"], []string{view.Labels["global"]}, 0, nil)
+	// New fields - "Proxy URL", "Username", "Password" & "No proxy"
+	formPrmt.AddInputField(view.Labels["proxy_url"], "", 0, nil, nil)
+	formPrmt.AddInputField(view.Labels["proxy_username"], "", 0, nil, nil)
+	formPrmt.AddPasswordField(view.Labels["proxy_password"], "", 0, '*', nil)
+	formPrmt.AddInputField(view.Labels["no_proxy"], "", 0, nil, nil)
+
+	// Add generic events to inputField
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["proxy_url"])
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["proxy_username"])
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["proxy_password"])
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["no_proxy"])
+
+	selectScope := func(text string, index int) {
+		if index != -1 {
+			resultPrmt.SetText("")
+			refreshForm(text)
+		}
+	}
+	utils.GetDropDownFieldForm(formPrmt, view.Labels["scope"]).SetSelectedFunc(selectScope)
+
+	// New field - "Save"
+	formPrmt.AddButton(view.Labels["save"], func() {
+		_, scope := utils.GetDropDownFieldForm(formPrmt, view.Labels["scope"]).GetCurrentOption()
+
+		proxy := models.Proxy{
+			URL:      strings.TrimSpace(utils.GetInputFieldForm(formPrmt, view.Labels["proxy_url"]).GetText()),
+			Username: utils.GetInputFieldForm(formPrmt, view.Labels["proxy_username"]).GetText(),
+			Password: utils.GetInputFieldForm(formPrmt, view.Labels["proxy_password"]).GetText(),
+			NoProxy:  utils.GetInputFieldForm(formPrmt, view.Labels["no_proxy"]).GetText(),
+		}
+		if error := proxy.Validate(); error != nil {
+			resultPrmt.SetText("[red]" + tview.Escape(error.Error()))
+			return
+		}
+
+		if scope == view.Labels["global"] {
+			config := view.AppCtx.GetConfig()
+			config.Proxy = proxy
+			view.AppCtx.UpdateConfig(config)
+		} else {
+			context := view.AppCtx.GetOutput().Context.Copy()
+			context.SetProxy(scope, proxy)
+			view.AppCtx.UpdateContext(context)
+		}
+		resultPrmt.SetText("[" + utils.GreenColorName + "]" + tview.Escape(scope) + " " + view.Labels["saved"])
+	})
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow)
+	flex.SetBorderPadding(1, 1, 1, 1)
+	flex.AddItem(descPrmt, 6, 0, false)
+	flex.AddItem(formPrmt, 0, 1, false)
+	flex.AddItem(resultPrmt, 1, 0, false)
+
+	// Refresh the scopes (execution contexts) & the selected settings
+	refresh := func() {
+		prmt := utils.GetDropDownFieldForm(formPrmt, view.Labels["scope"])
+		_, current := prmt.GetCurrentOption()
+
+		scopes := append([]string{view.Labels["global"]}, view.AppCtx.GetOutput().Context.GetEnvsName()...)
+		index := core.StringSlice(scopes).GetIndex(current)
+		if index == -1 {
+			index = 0
+		}
+		prmt.SetOptions(scopes, selectScope)
+		prmt.SetCurrentOption(index)
+	}
+
+	view.AppCtx.AddListenerConfig["makeProxyPage"] = func(data models.Config) {
+		view.AppCtx.PrintTrace("SettingsView.makeProxyPage{...}.listener")
+		refresh()
+	}
+	view.AppCtx.AddContextListener["makeProxyPage"] = func(data models.Context) {
+		refresh()
+	}
+
+	return flex
+}
+
+func (view *SettingsView) makeOAuth2Page(mapMenuToFocusPrmt map[string]tview.Primitive) *tview.Flex {
+	// Description prmt
+	descPrmt := tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+	descPrmt.SetText(view.Labels["oauth2_description"])
+	descPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	resultPrmt := tview.NewTextView().SetDynamicColors(true)
+	resultPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	// Form
+	formPrmt := tview.NewForm()
+	formPrmt.SetBorder(false)
+	formPrmt.SetBackgroundColor(utils.BackGrayColor)
+
+	mapMenuToFocusPrmt["menu_oauth2"] = formPrmt
+
+	fields := []string{"token_url", "client_id", "client_secret", "scopes", "oauth2_username", "oauth2_password"}
+
+	refreshForm := func(scope string) {
+		oauth2 := view.AppCtx.GetOutput().Context.GetOAuth2(scope)
+		index := models.OAuth2GrantTypes.GetIndex(oauth2.GrantType)
+		if index == -1 {
+			index = 0
+		}
+		utils.GetDropDownFieldForm(formPrmt, view.Labels["grant_type"]).SetCurrentOption(index)
+		for index, value := range []string{oauth2.TokenURL, oauth2.ClientID, oauth2.ClientSecret, oauth2.Scopes, oauth2.Username, oauth2.Password} {
+			utils.GetInputFieldForm(formPrmt, view.Labels[fields[index]]).SetText(value)
+		}
+	}
+
+	// New fields - "Scope" (execution context) & "Grant type"
+	formPrmt.AddDropDown(view.Labels["scope"], nil, 0, nil)
+	formPrmt.AddDropDown(view.Labels["grant_type"], models.OAuth2GrantTypes, 0, nil)
+	// New fields - "Token URL", "Client id", "Client secret", "Scopes", "Username" & "Password" (password grant)
+	formPrmt.AddInputField(view.Labels["token_url"], "", 0, nil, nil)
+	formPrmt.AddInputField(view.Labels["client_id"], "", 0, nil, nil)
+	formPrmt.AddPasswordField(view.Labels["client_secret"], "", 0, '*', nil)
+	formPrmt.AddInputField(view.Labels["scopes"], "", 0, nil, nil)
+	formPrmt.AddInputField(view.Labels["oauth2_username"], "", 0, nil, nil)
+	formPrmt.AddPasswordField(view.Labels["oauth2_password"], "", 0, '*', nil)
+
+	// Add generic events to inputField
+	for _, field := range fields {
+		utils.AddInputFieldEventForm(formPrmt, view.Labels[field])
+	}
+
+	selectScope := func(text string, index int) {
+		if index != -1 {
+			resultPrmt.SetText("")
+			refreshForm(text)
+		}
+	}
+
+	// New field - "Save"
+	formPrmt.AddButton(view.Labels["save"], func() {
+		index, scope := utils.GetDropDownFieldForm(formPrmt, view.Labels["scope"]).GetCurrentOption()
+		if index == -1 {
+			return
+		}
+		_, grantType := utils.GetDropDownFieldForm(formPrmt, view.Labels["grant_type"]).GetCurrentOption()
+
+		oauth2 := models.OAuth2{
+			GrantType:    grantType,
+			TokenURL:     strings.TrimSpace(utils.GetInputFieldForm(formPrmt, view.Labels["token_url"]).GetText()),
+			ClientID:     utils.GetInputFieldForm(formPrmt, view.Labels["client_id"]).GetText(),
+			ClientSecret: utils.GetInputFieldForm(formPrmt, view.Labels["client_secret"]).GetText(),
+			Scopes:       utils.GetInputFieldForm(formPrmt, view.Labels["scopes"]).GetText(),
+			Username:     utils.GetInputFieldForm(formPrmt, view.Labels["oauth2_username"]).GetText(),
+			Password:     utils.GetInputFieldForm(formPrmt, view.Labels["oauth2_password"]).GetText(),
+		}
+		if oauth2.IsEmpty() {
+			oauth2 = models.OAuth2{}
+		}
+		if error := oauth2.ReplaceContext(view.AppCtx.GetOutput().Context.GetAllKeyValue(scope)).Validate(); error != nil {
+			resultPrmt.SetText("[red]" + tview.Escape(error.Error()))
+			return
+		}
+
+		context := view.AppCtx.GetOutput().Context.Copy()
+		context.SetOAuth2(scope, oauth2)
+		view.AppCtx.UpdateContext(context)
+		resultPrmt.SetText("[" + utils.GreenColorName + "]" + tview.Escape(scope) + " " + view.Labels["saved"])
+	})
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow)
+	flex.SetBorderPadding(1, 1, 1, 1)
+	flex.AddItem(descPrmt, 6, 0, false)
+	flex.AddItem(formPrmt, 0, 1, false)
+	flex.AddItem(resultPrmt, 1, 0, false)
+
+	// Refresh the scopes (execution contexts) & the selected profile
+	view.AppCtx.AddContextListener["makeOAuth2Page"] = func(data models.Context) {
+		prmt := utils.GetDropDownFieldForm(formPrmt, view.Labels["scope"])
+		_, current := prmt.GetCurrentOption()
+
+		scopes := data.GetEnvsName()
+		index := scopes.GetIndex(current)
+		if index == -1 {
+			index = scopes.GetIndex("default")
+		}
+		prmt.SetOptions(scopes, selectScope)
+		prmt.SetCurrentOption(index)
+	}
+
+	return flex
+}
+
 func (view *SettingsView) makeEnvPage(mapMenuToFocusPrmt map[string]tview.Primitive) *tview.Flex {
 	// Overview displays the selected environment
-	overview := func(table *tview.Table, env string, data map[string]string) {
+	overview := func(table *tview.Table, env string, data []models.ContextVariable) {
 		table.Clear()
 
 		// Add Env value
@@ -275,10 +887,14 @@ func (view *SettingsView) makeEnvPage(mapMenuToFocusPrmt map[string]tview.Primit
 		// Add break line
 		table.SetCell(1, 0, tview.NewTableCell(""))
 
-		// Add all variables
+		// Add all variables (the secret values are masked)
 		var i = 2
-		for key, value := range data {
-			table.SetCell(i, 0, tview.NewTableCell(key).SetTextColor(tcell.ColorYellow))
+		for _, contextVariable := range data {
+			value := contextVariable.Value
+			if contextVariable.Secret {
+				value = models.SecretMask
+			}
+			table.SetCell(i, 0, tview.NewTableCell(contextVariable.Variable).SetTextColor(tcell.ColorYellow))
 			table.SetCell(i, 1, tview.NewTableCell(value))
 			i = i + 1
 		}
@@ -295,6 +911,9 @@ func (view *SettingsView) makeEnvPage(mapMenuToFocusPrmt map[string]tview.Primit
 
 	mapMenuToFocusPrmt["menu_env"] = formPrmt
 
+	resultPrmt := tview.NewTextView().SetDynamicColors(true)
+	resultPrmt.SetBackgroundColor(utils.BackGrayColor)
+
 	selectVariableDropDownPrmtOption := func(variable string) {
 		_, env := utils.GetDropDownFieldForm(formPrmt, view.Labels["envs"]).GetCurrentOption()
 
@@ -302,6 +921,7 @@ func (view *SettingsView) makeEnvPage(mapMenuToFocusPrmt map[string]tview.Primit
 
 		utils.GetInputFieldForm(formPrmt, view.Labels["variable"]).SetText(contextVariable.Variable)
 		utils.GetInputFieldForm(formPrmt, view.Labels["value"]).SetText(contextVariable.Value)
+		formPrmt.GetFormItemByLabel(view.Labels["secret"]).(*tview.Checkbox).SetChecked(contextVariable.Secret)
 		utils.GetInputFieldForm(formPrmt, view.Labels["new_env"]).SetText("")
 	}
 
@@ -331,7 +951,7 @@ func (view *SettingsView) makeEnvPage(mapMenuToFocusPrmt map[string]tview.Primit
 			displayDefault()
 		}
 
-		overview(table, env, view.AppCtx.GetOutput().Context.GetAllKeyValue(env))
+		overview(table, env, view.AppCtx.GetOutput().Context.Env[env])
 	}
 
 	refreshContext := func(env string, variable string) {
@@ -373,13 +993,18 @@ func (view *SettingsView) makeEnvPage(mapMenuToFocusPrmt map[string]tview.Primit
 	formPrmt.AddInputField(view.Labels["variable"], "", 0, nil, nil)
 	// New field - "Value"
 	formPrmt.AddInputField(view.Labels["value"], "", 0, nil, nil)
+	// New field - "Secret" (the value is saved encrypted in the secrets file)
+	formPrmt.AddCheckbox(view.Labels["secret"], false, nil)
 	// New field - "New Env"
 	formPrmt.AddInputField(view.Labels["new_env"], "", 0, nil, nil)
+	// New field - "Passphrase" (of the secrets file)
+	formPrmt.AddPasswordField(view.Labels["passphrase"], "", 0, '*', nil)
 
 	// Add generic events to inputField
 	utils.AddInputFieldEventForm(formPrmt, view.Labels["variable"])
 	utils.AddInputFieldEventForm(formPrmt, view.Labels["value"])
 	utils.AddInputFieldEventForm(formPrmt, view.Labels["new_env"])
+	utils.AddInputFieldEventForm(formPrmt, view.Labels["passphrase"])
 
 	// New field - "Add"
 	formPrmt.AddButton(view.Labels["add"], func() {
@@ -390,8 +1015,29 @@ func (view *SettingsView) makeEnvPage(mapMenuToFocusPrmt map[string]tview.Primit
 		if newEnv != "" {
 			env = newEnv
 		}
+		secret := formPrmt.GetFormItemByLabel(view.Labels["secret"]).(*tview.Checkbox).IsChecked()
+
 		context := view.AppCtx.GetOutput().Context
-		context.Add(env, variable, value)
+		previous := context.FindVariableByEnv(strings.ToLower(env), strings.ToLower(variable))
+		if secret {
+			// an empty value keeps the current secret value
+			if !previous.Secret || value != "" {
+				if error := view.SetSecret(env, variable, value); error != nil {
+					resultPrmt.SetText("[red]" + tview.Escape(error.Error()))
+					return
+				}
+			}
+			context.AddSecret(env, variable)
+		} else {
+			if previous.Secret {
+				if error := view.RemoveSecret(env, variable); error != nil {
+					resultPrmt.SetText("[red]" + tview.Escape(error.Error()))
+					return
+				}
+			}
+			context.Add(env, variable, value)
+		}
+		resultPrmt.SetText("")
 		view.AppCtx.UpdateContext(context)
 
 		refreshContext(env, variable)
@@ -403,6 +1049,13 @@ func (view *SettingsView) makeEnvPage(mapMenuToFocusPrmt map[string]tview.Primit
 		_, variable := utils.GetDropDownFieldForm(formPrmt, view.Labels["variables"]).GetCurrentOption()
 		if variable != "" {
 			context := view.AppCtx.GetOutput().Context
+			if context.FindVariableByEnv(env, variable).Secret {
+				if error := view.RemoveSecret(env, variable); error != nil {
+					resultPrmt.SetText("[red]" + tview.Escape(error.Error()))
+					return
+				}
+			}
+			resultPrmt.SetText("")
 			context.Remove(env, variable)
 			view.AppCtx.UpdateContext(context)
 
@@ -410,9 +1063,24 @@ func (view *SettingsView) makeEnvPage(mapMenuToFocusPrmt map[string]tview.Primit
 		}
 	})
 
+	// New field - "Unlock" (the secrets file with the passphrase)
+	formPrmt.AddButton(view.Labels["unlock"], func() {
+		passphraseFieldPrmt := utils.GetInputFieldForm(formPrmt, view.Labels["passphrase"])
+		if error := view.UnlockSecrets(passphraseFieldPrmt.GetText()); error != nil {
+			resultPrmt.SetText("[red]" + tview.Escape(error.Error()))
+			return
+		}
+		passphraseFieldPrmt.SetText("")
+		resultPrmt.SetText("[" + utils.GreenColorName + "]" + view.Labels["unlocked"])
+	})
+
+	formFlexPrmt := tview.NewFlex().SetDirection(tview.FlexRow)
+	formFlexPrmt.AddItem(formPrmt, 0, 1, false)
+	formFlexPrmt.AddItem(resultPrmt, 1, 0, false)
+
 	flex := tview.NewFlex()
 	flex.SetBorderPadding(1, 1, 1, 1)
-	flex.AddItem(formPrmt, 0, 1, false)
+	flex.AddItem(formFlexPrmt, 0, 1, false)
 	flex.AddItem(table, 0, 2, false)
 
 	view.AppCtx.AddContextListener["makeEnvPage"] = func(data models.Context) {